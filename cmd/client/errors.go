@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// printError writes a gRPC error along with its details in a human readable
+// form
+func printError(err error) {
+	st := status.Convert(err)
+	fmt.Fprintf(os.Stderr, "Error (%s): %s\n", st.Code(), st.Message())
+
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, v := range d.FieldViolations {
+				fmt.Fprintf(os.Stderr, "  invalid %s: %s\n", v.Field, v.Description)
+			}
+		case *errdetails.RetryInfo:
+			fmt.Fprintf(os.Stderr, "  retry after %s\n", d.RetryDelay.AsDuration())
+		case *errdetails.QuotaFailure:
+			for _, v := range d.Violations {
+				fmt.Fprintf(os.Stderr, "  quota exceeded for %s: %s\n", v.Subject, v.Description)
+			}
+		case *errdetails.ErrorInfo:
+			fmt.Fprintf(os.Stderr, "  reason: %s\n", d.Reason)
+		case *errdetails.ResourceInfo:
+			fmt.Fprintf(os.Stderr, "  resource: %s %s\n", d.ResourceType, d.ResourceName)
+			if d.Description != "" {
+				fmt.Fprintf(os.Stderr, "  %s\n", d.Description)
+			}
+		default:
+			fmt.Fprintf(os.Stderr, "  %v\n", d)
+		}
+	}
+}
@@ -2,32 +2,156 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"flag"
+	"fmt"
+	"io/ioutil"
 	"log"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/affanshahid/convoluted-movie-finder/core"
 	"github.com/affanshahid/convoluted-movie-finder/rpc/pb"
 	"github.com/davecgh/go-spew/spew"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var (
 	url          = flag.String("host", "localhost:50051", "URL of the movie-finder server")
 	genreId      = flag.Int64("g", 28, "ID of the genre to search into")
+	genreName    = flag.String("n", "", "Name of the genre to search into, used instead of -g")
+	language     = flag.String("l", "", "Language of genre names, e.g. de or pt-BR")
+	listGenres   = flag.Bool("list-genres", false, "List the available genres instead of searching")
+	moreGenres   = flag.String("and", "", "Comma separated genre ids or names searched together with -g or -n")
+	matchAny     = flag.Bool("any", false, "Match movies having any of the genres instead of all of them")
+	exclude      = flag.String("exclude", "", "Comma separated genre ids or names whose movies are left out")
 	startDateStr = flag.String("s", "2021-11-12", "Starting date of the search interval")
 	endDateStr   = flag.String("e", "2021-11-13", "Ending date of the search interval")
 	revenue      = flag.Int64("r", 1000, "Revenue threshold")
-	operator     = flag.Int("o", int(core.OpGt), "Operator to use when comparing revenue, 0: <, 1: ==, 2: >")
+	revenueMax   = flag.Int64("rmax", 0, "Upper revenue bound of the between operator")
+	minExclusive = flag.Bool("xmin", false, "Exclude the lower bound of the between operator")
+	maxExclusive = flag.Bool("xmax", false, "Exclude the upper bound of the between operator")
+	operator     = flag.Int("o", int(core.OpGt), "Operator to use when comparing revenue, 0: <, 1: ==, 2: >, 3: >=, 4: <=, 5: !=, 6: between -r and -rmax")
+	useTLS       = flag.Bool("tls", false, "Connect using TLS")
+	caFile       = flag.String("ca", "", "CA bundle used to verify the server, defaults to the system roots")
+	certFile     = flag.String("cert", "", "Client certificate to present for mutual TLS")
+	keyFile      = flag.String("key", "", "Private key of the client certificate")
+	token        = flag.String("token", "", "API key sent as a bearer token")
+	expression   = flag.String("x", "", "Expression movies must satisfy, used instead of -r and -o, e.g. 'revenue > 2 * budget && runtime < 100'")
+	sortBy       = flag.String("sort", "id", "Field movies are sorted by: id, revenue, release_date, title, vote_average or profit")
+	descending   = flag.Bool("desc", false, "Sort in descending order")
+	pageSize     = flag.Int("page-size", 0, "Number of movies per page, all of them when 0")
+	pageToken    = flag.String("page-token", "", "nextPageToken of the previous page")
+	fields       = flag.String("fields", "", "Comma separated movie fields to return, e.g. title,revenue,genres")
+	stats        = flag.Bool("stats", false, "Aggregate revenue, budget, runtime and rating over every matching movie")
+	anomalies    = flag.Bool("anomalies", false, "Flag the movies whose revenue looks wrong")
+	unknown      = flag.String("unknown", "as_zero", "How filters and expressions treat unknown (zero) revenues, budgets and runtimes: as_zero, excluded or included")
+	series       = flag.String("series", "", "Split the period into week, month, quarter or year buckets instead of listing movies")
+	compare      = flag.Bool("compare", false, "Compare the share of every genre, or of the -and genres, instead of listing movies")
+	denominator  = flag.String("denominator", "period", "Movies the share is relative to: period, genre or filtered")
+	top          = flag.Int("top", 0, "List the best N movies instead of listing them all")
+	rankBy       = flag.String("rank", "revenue", "Value -top ranks movies by: revenue, profit, roi, rating or popularity")
+	baselineFrom = flag.String("bs", "", "Starting date of a baseline period to compare the search interval with")
+	baselineTo   = flag.String("be", "", "Ending date of the baseline period, used with -bs")
+	cooccurrence = flag.Bool("cooccurrence", false, "Count how often every pair of genres is combined instead of listing movies")
+	weighted     = flag.Bool("weighted", false, "Also weight -cooccurrence by revenue, fetching the details of every movie")
+	filters      movieFilters
 )
 
+// movieFilters collects the repeated -f flag
+type movieFilters []*pb.MovieFilter
+
+func (f *movieFilters) String() string {
+	return fmt.Sprint(len(*f), " filters")
+}
+
+func (f *movieFilters) Set(value string) error {
+	filter, err := core.ParseMovieFilter(value)
+	if err != nil {
+		return err
+	}
+
+	msg := &pb.MovieFilter{
+		Field:    filter.Field,
+		Operator: pb.GenrePeriodDetailsRequest_Operator(filter.Operator),
+		Max:      filter.Max,
+	}
+
+	switch value := filter.Value.(type) {
+	case float64:
+		msg.Value = &pb.MovieFilter_Number{Number: value}
+	case string:
+		msg.Value = &pb.MovieFilter_Text{Text: value}
+	case bool:
+		msg.Value = &pb.MovieFilter_Flag{Flag: value}
+	}
+
+	*f = append(*f, msg)
+	return nil
+}
+
 func init() {
+	flag.Var(&filters, "f", "Filter on movie details used instead of -r and -o, written as field:operator:value or field:between:min:max, may be repeated")
 	flag.Parse()
 }
 
+func transportOption() grpc.DialOption {
+	if !*useTLS {
+		return grpc.WithInsecure()
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if *caFile != "" {
+		pem, err := ioutil.ReadFile(*caFile)
+		if err != nil {
+			log.Fatalf("unable to read CA bundle: %v", err)
+		}
+
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			log.Fatalf("no certificates found in %s", *caFile)
+		}
+	}
+
+	if *certFile != "" || *keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(*certFile, *keyFile)
+		if err != nil {
+			log.Fatalf("unable to load client certificate: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return grpc.WithTransportCredentials(credentials.NewTLS(config))
+}
+
+// splitGenres tells genre ids from genre names in a comma separated list
+func splitGenres(list string) (ids []int64, names []string) {
+	if list == "" {
+		return nil, nil
+	}
+
+	for _, genre := range strings.Split(list, ",") {
+		genre = strings.TrimSpace(genre)
+		if id, err := strconv.ParseInt(genre, 10, 64); err == nil {
+			ids = append(ids, id)
+		} else {
+			names = append(names, genre)
+		}
+	}
+
+	return ids, names
+}
+
 func main() {
-	conn, err := grpc.Dial(*url, grpc.WithInsecure())
+	conn, err := grpc.Dial(*url, transportOption())
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
@@ -36,6 +160,27 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	if *token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+	}
+
+	if *listGenres {
+		r, err := client.ListGenres(ctx, &pb.ListGenresRequest{Language: *language})
+		if err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+
+		for _, g := range r.Genres {
+			fmt.Printf("%6d  %s\n", g.Id, g.Name)
+		}
+		return
+	}
+
+	if *genreName != "" {
+		*genreId = 0
+	}
+
 	startTime, err := time.Parse("2006-01-02", *startDateStr)
 	if err != nil {
 		panic(err)
@@ -46,19 +191,189 @@ func main() {
 		panic(err)
 	}
 
-	if *operator > 2 || *operator < 0 {
-		panic("Operator must be 0,1 or 2")
+	if *operator > int(core.OpBetween) || *operator < 0 {
+		panic("Operator must be between 0 and 6")
 	}
 
-	r, err := client.FetchGenrePeriodDetails(ctx, &pb.GenrePeriodDetailsRequest{
+	if len(filters) > 0 || *expression != "" {
+		// filters and expressions replace the revenue comparison and its defaults
+		*revenue, *revenueMax, *operator = 0, 0, int(core.OpLt)
+		*minExclusive, *maxExclusive = false, false
+	}
+
+	sortField, ok := pb.GenrePeriodDetailsRequest_SortField_value["SORT_"+strings.ToUpper(*sortBy)]
+	if !ok {
+		panic("Unknown sort field " + *sortBy)
+	}
+
+	denominatorMode, ok := pb.GenrePeriodDetailsRequest_Denominator_value["DENOMINATOR_"+strings.ToUpper(*denominator)]
+	if !ok {
+		panic("Unknown denominator " + *denominator)
+	}
+
+	unknownValues, ok := pb.GenrePeriodDetailsRequest_UnknownValues_value["UNKNOWN_"+strings.ToUpper(*unknown)]
+	if !ok {
+		panic("Unknown policy " + *unknown)
+	}
+
+	var movieMask *fieldmaskpb.FieldMask
+	if *fields != "" {
+		movieMask = &fieldmaskpb.FieldMask{Paths: strings.Split(*fields, ",")}
+	}
+
+	genreIds, genreNames := splitGenres(*moreGenres)
+	excludedIds, excludedNames := splitGenres(*exclude)
+
+	if *compare {
+		r, err := client.CompareGenres(ctx, &pb.CompareGenresRequest{
+			GenreIds:   genreIds,
+			GenreNames: genreNames,
+			Language:   *language,
+			StartDate:  timestamppb.New(startTime),
+			EndDate:    timestamppb.New(endTime),
+			Filters:    filters,
+			Expression: *expression,
+		})
+		if err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+
+		for _, g := range r.Genres {
+			fmt.Printf("%6d  %-20s %6d %6.2f%% %16d\n", g.Id, g.Name, g.Movies, g.Pct, g.TotalRevenue)
+		}
+		return
+	}
+
+	if *cooccurrence {
+		r, err := client.GenreCooccurrence(ctx, &pb.GenreCooccurrenceRequest{
+			Language:        *language,
+			StartDate:       timestamppb.New(startTime),
+			EndDate:         timestamppb.New(endTime),
+			WeightByRevenue: *weighted,
+		})
+		if err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+
+		for i, row := range r.Rows {
+			for j, cell := range row.Cells {
+				if j > i && cell.Movies > 0 {
+					fmt.Printf("%-20s %-20s %6d %6.2f\n", r.Genres[i].Name, r.Genres[j].Name, cell.Movies, cell.Lift)
+				}
+			}
+		}
+		return
+	}
+
+	genreMatch := pb.GenrePeriodDetailsRequest_MATCH_ALL
+	if *matchAny {
+		genreMatch = pb.GenrePeriodDetailsRequest_MATCH_ANY
+	}
+
+	query := &pb.GenrePeriodDetailsRequest{
 		GenreId:              *genreId,
+		GenreName:            *genreName,
+		GenreIds:             genreIds,
+		GenreNames:           genreNames,
+		GenreMatch:           genreMatch,
+		ExcludedGenreIds:     excludedIds,
+		ExcludedGenreNames:   excludedNames,
+		Language:             *language,
 		StartDate:            timestamppb.New(startTime),
 		EndDate:              timestamppb.New(endTime),
 		Revenue:              *revenue,
+		RevenueMax:           *revenueMax,
+		RevenueMinExclusive:  *minExclusive,
+		RevenueMaxExclusive:  *maxExclusive,
 		RevenueCheckOperator: pb.GenrePeriodDetailsRequest_Operator(*operator),
-	})
+		Filters:              filters,
+		Expression:           *expression,
+		SortBy:               pb.GenrePeriodDetailsRequest_SortField(sortField),
+		Descending:           *descending,
+		PageSize:             int32(*pageSize),
+		PageToken:            *pageToken,
+		MovieMask:            movieMask,
+		IncludeStats:         *stats,
+		Denominator:          pb.GenrePeriodDetailsRequest_Denominator(denominatorMode),
+		DetectAnomalies:      *anomalies,
+		UnknownValues:        pb.GenrePeriodDetailsRequest_UnknownValues(unknownValues),
+	}
+
+	if *series != "" {
+		granularity, ok := pb.GenreSeriesRequest_Granularity_value["BY_"+strings.ToUpper(*series)]
+		if !ok {
+			panic("Unknown granularity " + *series)
+		}
+
+		r, err := client.FetchGenreSeries(ctx, &pb.GenreSeriesRequest{
+			Query:       query,
+			Granularity: pb.GenreSeriesRequest_Granularity(granularity),
+		})
+		if err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+
+		spew.Dump(r)
+		return
+	}
+
+	if *baselineFrom != "" {
+		baselineStart, err := time.Parse("2006-01-02", *baselineFrom)
+		if err != nil {
+			panic(err)
+		}
+
+		baselineEnd, err := time.Parse("2006-01-02", *baselineTo)
+		if err != nil {
+			panic(err)
+		}
+
+		r, err := client.ComparePeriods(ctx, &pb.ComparePeriodsRequest{
+			Query:             query,
+			BaselineStartDate: timestamppb.New(baselineStart),
+			BaselineEndDate:   timestamppb.New(baselineEnd),
+		})
+		if err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+
+		spew.Dump(r.Deltas, r.OnlyInCurrent, r.OnlyInBaseline)
+		return
+	}
+
+	if *top > 0 {
+		metric, ok := pb.TopMoviesRequest_Metric_value["RANK_"+strings.ToUpper(*rankBy)]
+		if !ok {
+			panic("Unknown ranking metric " + *rankBy)
+		}
+
+		r, err := client.TopMovies(ctx, &pb.TopMoviesRequest{
+			Query:  query,
+			Metric: pb.TopMoviesRequest_Metric(metric),
+			Limit:  int32(*top),
+		})
+		if err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+
+		for _, m := range r.Movies {
+			fmt.Printf("%4d  %16.2f  %s\n", m.Rank, m.Value, m.Movie.Title)
+		}
+		if r.OmittedTies > 0 {
+			fmt.Printf("and %d more tied for the last rank\n", r.OmittedTies)
+		}
+		return
+	}
+
+	r, err := client.FetchGenrePeriodDetails(ctx, query)
 	if err != nil {
-		panic(err)
+		printError(err)
+		os.Exit(1)
 	}
 
 	spew.Dump(r)
@@ -2,6 +2,7 @@ package main
 
 import (
 	"os"
+	"time"
 
 	"github.com/affanshahid/configo"
 	"github.com/affanshahid/convoluted-movie-finder/core"
@@ -25,10 +26,45 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	s := core.NewMovieService(c, cache)
+	s := core.NewMovieService(
+		c,
+		cache,
+		core.WithMaxPeriodSpan(time.Duration(configo.MustGetInt("max_period_days"))*24*time.Hour),
+		core.WithGenreCacheTTL(configo.MustGetDuration("genre_cache_ttl")),
+		core.WithExpressionLimits(
+			configo.MustGetInt("filter_expression_max_length"),
+			configo.MustGetInt("filter_expression_max_nodes"),
+		),
+		core.WithMaxConcurrency(configo.MustGetInt("tmdb_max_concurrency")),
+	)
 
-	err = rpc.Serve(s)
-	if err != nil {
+	var keyStore core.KeyStore
+	if configo.MustGetBool("auth_enabled") {
+		etcdKeyStore, err := core.NewKeyStoreEtcd()
+		if err != nil {
+			panic(err)
+		}
+		defer etcdKeyStore.Close()
+		keyStore = etcdKeyStore
+	}
+
+	var quotaStore core.QuotaStore
+	if configo.MustGetBool("rate_limit_enabled") {
+		etcdQuotaStore, err := core.NewQuotaStoreEtcd()
+		if err != nil {
+			panic(err)
+		}
+		defer etcdQuotaStore.Close()
+		quotaStore = etcdQuotaStore
+	}
+
+	serveErr := rpc.Serve(s, keyStore, quotaStore)
+
+	if err := cache.Close(); err != nil {
 		panic(err)
 	}
+
+	if serveErr != nil {
+		panic(serveErr)
+	}
 }
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+const (
+	// outlierThreshold is the robust z-score beyond which a revenue is an
+	// outlier, as suggested by Iglewicz and Hoaglin
+	outlierThreshold = 3.5
+	// minOutlierSample is the number of known revenues needed to look for
+	// outliers
+	minOutlierSample = 5
+	// iqrFenceFactor places Tukey's far out fences, used when most revenues
+	// are equal and the robust z-score can't be computed
+	iqrFenceFactor = 3
+	// tinyRevenueLimit is the revenue below which a known revenue was likely
+	// entered in thousands or millions
+	tinyRevenueLimit = 1000
+)
+
+// AnomalyKind tells why the revenue of a movie looks wrong
+type AnomalyKind uint8
+
+const (
+	// AnomalyOutlier is a revenue orders of magnitude away from the other
+	// movies of the result
+	AnomalyOutlier AnomalyKind = iota
+	// AnomalyRevenueEqualsBudget is a revenue likely copied from the budget
+	AnomalyRevenueEqualsBudget
+	// AnomalyTinyRevenue is a known revenue below tinyRevenueLimit
+	AnomalyTinyRevenue
+)
+
+// Anomaly is one reason to doubt the revenue of a movie, Kind for machines and
+// Reason, with the figures involved, for people
+type Anomaly struct {
+	Kind   AnomalyKind
+	Reason string
+}
+
+// detectAnomalies flags the movies whose revenue looks wrong, by TMDB id.
+// Outliers are found with the robust z-score of the logarithm of the known
+// revenues, so that a revenue entered in thousands stands out while
+// blockbusters don't. When more than half the revenues are equal the
+// interquartile range takes over, and when it is zero too no revenue is an
+// outlier.
+func detectAnomalies(movies []*tmdb.MovieDetails) map[int64][]Anomaly {
+	anomalies := map[int64][]Anomaly{}
+
+	var logs []float64
+	for _, movie := range movies {
+		if movie.Revenue > 0 {
+			logs = append(logs, math.Log10(float64(movie.Revenue)))
+		}
+	}
+
+	outlier := func(revenue int64) (string, bool) { return "", false }
+	if len(logs) >= minOutlierSample {
+		sort.Float64s(logs)
+		outlier = outlierTest(logs)
+	}
+
+	for _, movie := range movies {
+		if movie.Revenue <= 0 {
+			continue
+		}
+
+		if reason, ok := outlier(movie.Revenue); ok {
+			anomalies[movie.ID] = append(anomalies[movie.ID], Anomaly{Kind: AnomalyOutlier, Reason: reason})
+		}
+
+		if movie.Revenue == movie.Budget {
+			anomalies[movie.ID] = append(anomalies[movie.ID], Anomaly{
+				Kind:   AnomalyRevenueEqualsBudget,
+				Reason: fmt.Sprintf("revenue equals the budget of %d, it was likely copied from it", movie.Budget),
+			})
+		}
+
+		if movie.Revenue < tinyRevenueLimit {
+			anomalies[movie.ID] = append(anomalies[movie.ID], Anomaly{
+				Kind:   AnomalyTinyRevenue,
+				Reason: fmt.Sprintf("revenue of %d is below %d, it was likely entered in thousands or millions", movie.Revenue, tinyRevenueLimit),
+			})
+		}
+	}
+
+	return anomalies
+}
+
+// outlierTest returns how to tell outlying revenues given the sorted
+// logarithms of the known ones
+func outlierTest(logs []float64) func(revenue int64) (string, bool) {
+	median := percentile(logs, 50)
+
+	deviations := make([]float64, len(logs))
+	for i, l := range logs {
+		deviations[i] = math.Abs(l - median)
+	}
+	sort.Float64s(deviations)
+
+	if mad := percentile(deviations, 50); mad > 0 {
+		return func(revenue int64) (string, bool) {
+			score := 0.6745 * (math.Log10(float64(revenue)) - median) / mad
+			if math.Abs(score) <= outlierThreshold {
+				return "", false
+			}
+
+			return fmt.Sprintf("revenue of %d has a robust z-score of %.1f against a median of %.0f",
+				revenue, score, math.Pow(10, median)), true
+		}
+	}
+
+	q1, q3 := percentile(logs, 25), percentile(logs, 75)
+	iqr := q3 - q1
+	lower, upper := q1-iqrFenceFactor*iqr, q3+iqrFenceFactor*iqr
+
+	return func(revenue int64) (string, bool) {
+		l := math.Log10(float64(revenue))
+		if iqr == 0 || l >= lower && l <= upper {
+			return "", false
+		}
+
+		return fmt.Sprintf("revenue of %d is outside the interquartile fences of %.0f and %.0f",
+			revenue, math.Pow(10, lower), math.Pow(10, upper)), true
+	}
+}
@@ -0,0 +1,153 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/affanshahid/convoluted-movie-finder/core/mocks"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func anomalyKinds(anomalies map[int64][]Anomaly) map[int64][]AnomalyKind {
+	kinds := map[int64][]AnomalyKind{}
+	for id, list := range anomalies {
+		for _, a := range list {
+			kinds[id] = append(kinds[id], a.Kind)
+		}
+	}
+
+	return kinds
+}
+
+func TestDetectAnomalies(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		movies   []*tmdb.MovieDetails
+		expected map[int64][]AnomalyKind
+	}{
+		{
+			"revenue entered in thousands",
+			[]*tmdb.MovieDetails{
+				{ID: 1, Revenue: 100_000_000},
+				{ID: 2, Revenue: 250_000_000},
+				{ID: 3, Revenue: 80_000_000},
+				{ID: 4, Revenue: 1_200_000_000},
+				{ID: 5, Revenue: 40_000_000},
+				{ID: 6, Revenue: 120_000},
+				{ID: 7},
+			},
+			map[int64][]AnomalyKind{6: {AnomalyOutlier}},
+		},
+		{
+			"revenue copied from budget",
+			[]*tmdb.MovieDetails{
+				{ID: 1, Revenue: 30_000_000, Budget: 30_000_000},
+				{ID: 2, Revenue: 50_000_000, Budget: 20_000_000},
+			},
+			map[int64][]AnomalyKind{1: {AnomalyRevenueEqualsBudget}},
+		},
+		{
+			"tiny revenues",
+			[]*tmdb.MovieDetails{
+				{ID: 1, Revenue: 12},
+				{ID: 2, Revenue: 1000},
+			},
+			map[int64][]AnomalyKind{1: {AnomalyTinyRevenue}},
+		},
+		{
+			"most revenues equal",
+			[]*tmdb.MovieDetails{
+				{ID: 1, Revenue: 100_000_000},
+				{ID: 2, Revenue: 100_000_000},
+				{ID: 3, Revenue: 100_000_000},
+				{ID: 4, Revenue: 80_000_000},
+				{ID: 5, Revenue: 100_000_000},
+				{ID: 6, Revenue: 120_000_000},
+				{ID: 7, Revenue: 150_000},
+			},
+			map[int64][]AnomalyKind{7: {AnomalyOutlier}},
+		},
+		{
+			"three quarters of the revenues equal",
+			[]*tmdb.MovieDetails{
+				{ID: 1, Revenue: 100_000_000},
+				{ID: 2, Revenue: 100_000_000},
+				{ID: 3, Revenue: 100_000_000},
+				{ID: 4, Revenue: 100_000_000},
+				{ID: 5, Revenue: 100_000_000},
+				{ID: 6, Revenue: 150_000},
+			},
+			map[int64][]AnomalyKind{},
+		},
+		{
+			"too few revenues for outliers",
+			[]*tmdb.MovieDetails{
+				{ID: 1, Revenue: 100_000_000},
+				{ID: 2, Revenue: 120_000_000},
+				{ID: 3, Revenue: 90_000_000},
+				{ID: 4, Revenue: 5_000},
+			},
+			map[int64][]AnomalyKind{},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, c.expected, anomalyKinds(detectAnomalies(c.movies)))
+		})
+	}
+}
+
+func TestFetchGenrePeriodDetailsFlagsAnomaliesOfThePage(t *testing.T) {
+	t.Parallel()
+	mockClient := new(mocks.TmdbClient)
+	mockCache := new(mocks.MovieCache)
+
+	var nilmap map[string]string
+
+	mockCache.On("GetMovieDetails", mock.Anything).Return(nil, nil)
+	mockCache.On("SaveMovieDetails", mock.Anything).Return(nil)
+
+	mockClient.On("GetGenreMovieList", nilmap).Return(genreList, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+	}).Return(allMoviesDiscover, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"with_genres":      "28",
+	}).Return(allMoviesDiscover, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"with_genres":      "28",
+		"page":             "1",
+	}).Return(allMoviesDiscover, nil)
+	mockClient.On("GetMovieDetails", 1, nilmap).Return(&tmdb.MovieDetails{ID: 1, Revenue: 5000, Budget: 5000}, nil)
+	mockClient.On("GetMovieDetails", 2, nilmap).Return(&tmdb.MovieDetails{ID: 2, Revenue: 8000}, nil)
+	mockClient.On("GetMovieDetails", 3, nilmap).Return(&tmdb.MovieDetails{ID: 3, Revenue: 10}, nil)
+	mockClient.On("GetMovieDetails", 4, nilmap).Return(&tmdb.MovieDetails{ID: 4, Revenue: 9000}, nil)
+
+	svc := NewMovieService(mockClient, mockCache)
+
+	result, err := svc.FetchGenrePeriodDetails(context.Background(), GenrePeriodQuery{
+		GenreId:         28,
+		StartDate:       startDate,
+		EndDate:         endDate,
+		Filters:         []MovieFilter{{Field: "revenue", Operator: OpGt, Value: float64(0)}},
+		PageSize:        2,
+		DetectAnomalies: true,
+	})
+
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.Equal(t, []int64{1, 2}, movieIds(result.Movies))
+	assert.Equal(t, map[int64][]AnomalyKind{1: {AnomalyRevenueEqualsBudget}}, anomalyKinds(result.Anomalies))
+	assert.Equal(t, 2, result.AnomalousMovies)
+}
@@ -0,0 +1,164 @@
+package core
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/antonmedv/expr/vm"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"golang.org/x/sync/errgroup"
+)
+
+// GenreComparisonQuery compares the given genres, or every genre when none are
+// given, among the movies released in a period. Movies must pass every one of
+// Filters and Expression, there is no revenue comparison to fall back to.
+type GenreComparisonQuery struct {
+	GenreIds   []int64
+	GenreNames []string
+	Language   string
+	StartDate  time.Time
+	EndDate    time.Time
+	Filters    []MovieFilter
+	Expression string
+}
+
+// GenreComparison holds a share for every compared genre, from the most to the
+// least movies. TotalMovies counts every movie released in the period and
+// MatchingMovies those of any compared genre which pass the filters, a movie
+// having several genres counts towards each of them.
+type GenreComparison struct {
+	TotalMovies    int64
+	MatchingMovies int
+	Genres         []GenreShare
+}
+
+// GenreShare leaves movies with an unknown (zero) revenue out of TotalRevenue,
+// they are counted in UnknownRevenue
+type GenreShare struct {
+	Genre
+	Movies         int
+	Pct            float64
+	TotalRevenue   int64
+	UnknownRevenue int
+}
+
+// CompareGenres lists the movies of all compared genres at once, so that the
+// details of a movie having several of them are only fetched once, and counts
+// the movies released in the period a single time
+func (s *MovieService) CompareGenres(ctx context.Context, query GenreComparisonQuery) (GenreComparison, error) {
+	var comparison GenreComparison
+
+	expression, err := s.validateComparisonQuery(query)
+	if err != nil {
+		return comparison, err
+	}
+
+	genres, err := s.ListGenres(ctx, query.Language)
+	if err != nil {
+		return comparison, err
+	}
+
+	options := map[string]string{
+		"release_date.gte": query.StartDate.Format(timeFormat),
+		"release_date.lte": query.EndDate.Format(timeFormat),
+	}
+
+	if len(query.GenreIds) > 0 || len(query.GenreNames) > 0 {
+		genres, err = lookupGenres(genres, query.GenreIds, query.GenreNames)
+		if err != nil {
+			return comparison, err
+		}
+		options["with_genres"] = joinGenreIds(genres, "|")
+	}
+
+	plan := planQuery(query.Filters, options, expression)
+
+	var movies []*tmdb.MovieDetails
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		var err error
+		comparison.TotalMovies, err = s.getTotalMoviesInPeriod(egCtx, query.StartDate, query.EndDate)
+		return err
+	})
+	eg.Go(func() error {
+		var err error
+		movies, err = s.fetchMovies(egCtx, plan)
+		return err
+	})
+
+	if err := eg.Wait(); err != nil {
+		return comparison, err
+	}
+
+	comparison.Genres = shareGenres(genres, movies, comparison.TotalMovies)
+	for _, movie := range movies {
+		for _, g := range movie.Genres {
+			if containsGenre(genres, g.ID) {
+				comparison.MatchingMovies++
+				break
+			}
+		}
+	}
+
+	return comparison, nil
+}
+
+func (s *MovieService) validateComparisonQuery(query GenreComparisonQuery) (*vm.Program, error) {
+	var v violations
+
+	v.addGenreIds("genreIds", query.GenreIds)
+	v.addGenreNames("genreNames", query.GenreNames)
+
+	v.addLanguage(query.Language)
+
+	s.addPeriodViolations(&v, query.StartDate, query.EndDate)
+
+	// without filters nor an expression the query is checked as matching
+	// every movie, its zero revenue comparison is never applied
+	expression := s.addFilterViolations(&v, GenrePeriodQuery{Filters: query.Filters, Expression: query.Expression})
+
+	return expression, v.err()
+}
+
+// shareGenres counts the movies of every genre, ordered from the most to the
+// least movies and by id for equal counts
+func shareGenres(genres []Genre, movies []*tmdb.MovieDetails, total int64) []GenreShare {
+	shares := make([]GenreShare, 0, len(genres))
+	index := map[int64]int{}
+	for _, g := range genres {
+		index[g.Id] = len(shares)
+		shares = append(shares, GenreShare{Genre: g})
+	}
+
+	for _, movie := range movies {
+		for _, g := range movie.Genres {
+			i, ok := index[g.ID]
+			if !ok {
+				continue
+			}
+
+			shares[i].Movies++
+			if movie.Revenue == 0 {
+				shares[i].UnknownRevenue++
+			} else {
+				shares[i].TotalRevenue += movie.Revenue
+			}
+		}
+	}
+
+	for i := range shares {
+		if total > 0 {
+			shares[i].Pct = float64(shares[i].Movies) / float64(total) * 100
+		}
+	}
+
+	sort.SliceStable(shares, func(i, j int) bool {
+		if shares[i].Movies != shares[j].Movies {
+			return shares[i].Movies > shares[j].Movies
+		}
+		return shares[i].Id < shares[j].Id
+	})
+
+	return shares
+}
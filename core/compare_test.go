@@ -0,0 +1,150 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/affanshahid/convoluted-movie-finder/core/mocks"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func movieWithGenres(id, revenue int64, genres ...Genre) *tmdb.MovieDetails {
+	movie := &tmdb.MovieDetails{ID: id, Revenue: revenue}
+	for _, g := range genres {
+		movie.Genres = append(movie.Genres, struct {
+			ID   int64  `json:"id"`
+			Name string `json:"name"`
+		}{g.Id, g.Name})
+	}
+
+	return movie
+}
+
+func comparisonClient() *mocks.TmdbClient {
+	action, scifi := Genre{28, "Action"}, Genre{29, "Sci-fi"}
+	mockClient := new(mocks.TmdbClient)
+
+	var nilmap map[string]string
+	mockClient.On("GetGenreMovieList", nilmap).Return(genreList, nil)
+	mockClient.On("GetMovieDetails", 1, nilmap).Return(movieWithGenres(1, 1000, action), nil)
+	mockClient.On("GetMovieDetails", 2, nilmap).Return(movieWithGenres(2, 0, action, scifi), nil)
+	mockClient.On("GetMovieDetails", 3, nilmap).Return(movieWithGenres(3, 500, scifi), nil)
+	mockClient.On("GetMovieDetails", 4, nilmap).Return(movieWithGenres(4, 10), nil)
+
+	return mockClient
+}
+
+func comparisonCache() *mocks.MovieCache {
+	mockCache := new(mocks.MovieCache)
+	mockCache.On("GetMovieDetails", mock.Anything).Return(nil, nil)
+	mockCache.On("SaveMovieDetails", mock.Anything).Return(nil)
+
+	return mockCache
+}
+
+func TestCompareGenres(t *testing.T) {
+	t.Parallel()
+	mockClient := comparisonClient()
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+	}).Return(allMoviesDiscover, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"page":             "1",
+	}).Return(allMoviesDiscover, nil)
+
+	svc := NewMovieService(mockClient, comparisonCache())
+
+	result, err := svc.CompareGenres(context.Background(), GenreComparisonQuery{StartDate: startDate, EndDate: endDate})
+
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.Equal(t, GenreComparison{
+		TotalMovies:    4,
+		MatchingMovies: 3,
+		Genres: []GenreShare{
+			{Genre: Genre{28, "Action"}, Movies: 2, Pct: 50, TotalRevenue: 1000, UnknownRevenue: 1},
+			{Genre: Genre{29, "Sci-fi"}, Movies: 2, Pct: 50, TotalRevenue: 500, UnknownRevenue: 1},
+		},
+	}, result)
+	mockClient.AssertNumberOfCalls(t, "GetMovieDetails", 4)
+}
+
+func TestCompareGenresSubsetWithFilters(t *testing.T) {
+	t.Parallel()
+	mockClient := comparisonClient()
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+	}).Return(allMoviesDiscover, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"with_genres":      "29",
+	}).Return(scifiMoviesDiscover, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"with_genres":      "29",
+		"page":             "1",
+	}).Return(scifiMoviesDiscover, nil)
+
+	svc := NewMovieService(mockClient, comparisonCache())
+
+	result, err := svc.CompareGenres(context.Background(), GenreComparisonQuery{
+		GenreNames: []string{"sci-fi"},
+		StartDate:  startDate,
+		EndDate:    endDate,
+		Filters:    []MovieFilter{{Field: "revenue", Operator: OpGt, Value: float64(0)}},
+	})
+
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.Equal(t, GenreComparison{
+		TotalMovies:    4,
+		MatchingMovies: 1,
+		Genres:         []GenreShare{{Genre: Genre{29, "Sci-fi"}, Movies: 1, Pct: 25, TotalRevenue: 500}},
+	}, result)
+}
+
+func TestCompareGenresRejectsInvalidQueries(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache))
+
+	_, err := svc.CompareGenres(context.Background(), GenreComparisonQuery{
+		GenreIds:  []int64{-1},
+		StartDate: endDate,
+		EndDate:   startDate,
+		Filters:   []MovieFilter{{Field: "gross", Operator: OpGt, Value: float64(0)}},
+	})
+
+	var invalidErr *InvalidArgumentError
+	assert.True(t, errors.As(err, &invalidErr), "expected an InvalidArgumentError")
+	assert.Equal(t, []FieldViolation{
+		{Field: "genreIds", Description: "must only contain positive TMDB genre ids"},
+		{Field: "startDate", Description: "must not be after endDate"},
+		{Field: "filters[0].field", Description: "must be one of " + strings.Join(FilterFields(), ", ")},
+	}, invalidErr.Violations)
+}
+
+func TestShareGenresOrdersByMovies(t *testing.T) {
+	t.Parallel()
+	drama, comedy, horror := Genre{18, "Drama"}, Genre{35, "Comedy"}, Genre{27, "Horror"}
+	movies := []*tmdb.MovieDetails{
+		movieWithGenres(1, 10, comedy),
+		movieWithGenres(2, 10, comedy, drama),
+		movieWithGenres(3, 10, horror),
+	}
+
+	shares := shareGenres([]Genre{drama, comedy, horror}, movies, 0)
+
+	assert.Equal(t, []GenreShare{
+		{Genre: comedy, Movies: 2, TotalRevenue: 20},
+		{Genre: drama, Movies: 1, TotalRevenue: 10},
+		{Genre: horror, Movies: 1, TotalRevenue: 10},
+	}, shares)
+}
@@ -0,0 +1,231 @@
+package core
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"golang.org/x/sync/errgroup"
+)
+
+// GenreCooccurrenceQuery counts how often genres are combined among the movies
+// released in a period. WeightByRevenue also sums the revenue of the movies,
+// which needs their details and costs a TMDB call for every movie missing from
+// the cache.
+type GenreCooccurrenceQuery struct {
+	Language        string
+	StartDate       time.Time
+	EndDate         time.Time
+	WeightByRevenue bool
+}
+
+// GenreCooccurrence holds a row and a column for every genre, in the order of
+// Genres. Movies counts the movies walked, UnknownRevenue those left out of
+// the revenue weights because TMDB reports their revenue as zero.
+type GenreCooccurrence struct {
+	Genres         []Genre
+	Matrix         [][]CooccurrenceCell
+	Movies         int64
+	TotalRevenue   int64
+	UnknownRevenue int64
+}
+
+// CooccurrenceCell describes the movies having both the genre of its row and
+// that of its column, the diagonal describes the movies of a single genre.
+// Lift compares how often both genres are combined with how often they would
+// be if they were independent, above 1 meaning more often. RevenueLift does
+// the same with the share of revenue instead of the share of movies, both are
+// zero when either genre has no movies.
+type CooccurrenceCell struct {
+	Movies      int64
+	Lift        float64
+	Revenue     int64
+	RevenueLift float64
+}
+
+// FetchGenreCooccurrence lists the movies of the period through the discover
+// endpoint, whose results already hold their genres, so that details are only
+// fetched when weighting by revenue
+func (s *MovieService) FetchGenreCooccurrence(ctx context.Context, query GenreCooccurrenceQuery) (GenreCooccurrence, error) {
+	var cooccurrence GenreCooccurrence
+
+	if err := s.validateCooccurrenceQuery(query); err != nil {
+		return cooccurrence, err
+	}
+
+	genres, err := s.ListGenres(ctx, query.Language)
+	if err != nil {
+		return cooccurrence, err
+	}
+
+	matrix := newCooccurrenceMatrix(genres)
+	options := map[string]string{
+		"release_date.gte": query.StartDate.Format(timeFormat),
+		"release_date.lte": query.EndDate.Format(timeFormat),
+	}
+
+	if query.WeightByRevenue {
+		err = s.streamMovies(ctx, &queryPlan{discoverOptions: options}, func(movie *tmdb.MovieDetails) {
+			ids := make([]int64, 0, len(movie.Genres))
+			for _, g := range movie.Genres {
+				ids = append(ids, g.ID)
+			}
+			matrix.add(ids, movie.Revenue, true)
+		})
+	} else {
+		err = s.walkDiscover(ctx, options, func(genreIds []int64) {
+			matrix.add(genreIds, 0, false)
+		})
+	}
+	if err != nil {
+		return cooccurrence, err
+	}
+
+	cooccurrence.Genres = genres
+	cooccurrence.Matrix = matrix.cells()
+	cooccurrence.Movies = matrix.movies
+	cooccurrence.TotalRevenue = matrix.revenue
+	cooccurrence.UnknownRevenue = matrix.unknownRevenue
+
+	return cooccurrence, nil
+}
+
+func (s *MovieService) validateCooccurrenceQuery(query GenreCooccurrenceQuery) error {
+	var v violations
+
+	v.addLanguage(query.Language)
+
+	s.addPeriodViolations(&v, query.StartDate, query.EndDate)
+
+	return v.err()
+}
+
+// walkDiscover calls visit with the genres of every movie the discover options
+// select, without fetching their details. The first page comes with the page
+// count, the others are fetched concurrently and visited one at a time as they
+// arrive.
+func (s *MovieService) walkDiscover(ctx context.Context, options map[string]string, visit func(genreIds []int64)) error {
+	result, err := s.clientFor(ctx).GetDiscoverMovie(options)
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	visitPage := func(page *tmdb.DiscoverMovie) {
+		if page.DiscoverMovieResults == nil {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		for _, movie := range page.Results {
+			visit(movie.GenreIDs)
+		}
+	}
+
+	visitPage(result)
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for i := int64(2); i <= result.TotalPages; i++ {
+		page := i
+		eg.Go(func() error {
+			if err := egCtx.Err(); err != nil {
+				return err
+			}
+
+			pageOptions := map[string]string{"page": strconv.FormatInt(page, 10)}
+			for key, value := range options {
+				pageOptions[key] = value
+			}
+
+			result, err := s.clientFor(egCtx).GetDiscoverMovie(pageOptions)
+			if err != nil {
+				return err
+			}
+
+			visitPage(result)
+			return nil
+		})
+	}
+
+	return eg.Wait()
+}
+
+// cooccurrenceMatrix accumulates the movies and revenue of every pair of
+// genres, genres missing from the genre list are ignored
+type cooccurrenceMatrix struct {
+	index          map[int64]int
+	movies         int64
+	revenue        int64
+	unknownRevenue int64
+	pairMovies     [][]int64
+	pairRevenue    [][]int64
+}
+
+func newCooccurrenceMatrix(genres []Genre) *cooccurrenceMatrix {
+	m := &cooccurrenceMatrix{
+		index:       make(map[int64]int, len(genres)),
+		pairMovies:  make([][]int64, len(genres)),
+		pairRevenue: make([][]int64, len(genres)),
+	}
+
+	for i, g := range genres {
+		m.index[g.Id] = i
+		m.pairMovies[i] = make([]int64, len(genres))
+		m.pairRevenue[i] = make([]int64, len(genres))
+	}
+
+	return m
+}
+
+func (m *cooccurrenceMatrix) add(genreIds []int64, revenue int64, weighted bool) {
+	m.movies++
+	if weighted && revenue == 0 {
+		m.unknownRevenue++
+	}
+	m.revenue += revenue
+
+	seen := map[int]bool{}
+	var rows []int
+	for _, id := range genreIds {
+		if i, ok := m.index[id]; ok && !seen[i] {
+			seen[i] = true
+			rows = append(rows, i)
+		}
+	}
+
+	for _, i := range rows {
+		for _, j := range rows {
+			m.pairMovies[i][j]++
+			m.pairRevenue[i][j] += revenue
+		}
+	}
+}
+
+func (m *cooccurrenceMatrix) cells() [][]CooccurrenceCell {
+	cells := make([][]CooccurrenceCell, len(m.pairMovies))
+	for i := range m.pairMovies {
+		cells[i] = make([]CooccurrenceCell, len(m.pairMovies))
+		for j := range m.pairMovies {
+			cells[i][j] = CooccurrenceCell{
+				Movies:      m.pairMovies[i][j],
+				Lift:        lift(m.pairMovies[i][j], m.pairMovies[i][i], m.pairMovies[j][j], m.movies),
+				Revenue:     m.pairRevenue[i][j],
+				RevenueLift: lift(m.pairRevenue[i][j], m.pairRevenue[i][i], m.pairRevenue[j][j], m.revenue),
+			}
+		}
+	}
+
+	return cells
+}
+
+// lift is the share of both genres relative to the product of their shares
+func lift(both, first, second, total int64) float64 {
+	if first == 0 || second == 0 {
+		return 0
+	}
+
+	return float64(both) * float64(total) / (float64(first) * float64(second))
+}
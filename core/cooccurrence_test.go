@@ -0,0 +1,151 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/affanshahid/convoluted-movie-finder/core/mocks"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/stretchr/testify/assert"
+)
+
+// genreDiscover lists movies 1 to 4: Action, Action and Sci-fi, Sci-fi and an
+// unknown genre
+var genreDiscover = func() *tmdb.DiscoverMovie {
+	var result tmdb.DiscoverMovie
+	err := json.Unmarshal([]byte(`{
+		"page": 1,
+		"total_pages": 1,
+		"total_results": 4,
+		"results": [
+			{"id": 1, "genre_ids": [28]},
+			{"id": 2, "genre_ids": [28, 29]},
+			{"id": 3, "genre_ids": [29]},
+			{"id": 4, "genre_ids": [99]}
+		]
+	}`), &result)
+	if err != nil {
+		panic(err)
+	}
+
+	return &result
+}()
+
+func cooccurrenceClient() *mocks.TmdbClient {
+	mockClient := new(mocks.TmdbClient)
+
+	var nilmap map[string]string
+	mockClient.On("GetGenreMovieList", nilmap).Return(genreList, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+	}).Return(genreDiscover, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"page":             "1",
+	}).Return(genreDiscover, nil)
+
+	return mockClient
+}
+
+func TestFetchGenreCooccurrence(t *testing.T) {
+	t.Parallel()
+	mockClient := cooccurrenceClient()
+	svc := NewMovieService(mockClient, new(mocks.MovieCache))
+
+	result, err := svc.FetchGenreCooccurrence(context.Background(), GenreCooccurrenceQuery{StartDate: startDate, EndDate: endDate})
+
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.Equal(t, GenreCooccurrence{
+		Genres: []Genre{{28, "Action"}, {29, "Sci-fi"}},
+		Matrix: [][]CooccurrenceCell{
+			{{Movies: 2, Lift: 2}, {Movies: 1, Lift: 1}},
+			{{Movies: 1, Lift: 1}, {Movies: 2, Lift: 2}},
+		},
+		Movies: 4,
+	}, result)
+	mockClient.AssertNotCalled(t, "GetMovieDetails")
+	mockClient.AssertNumberOfCalls(t, "GetDiscoverMovie", 1)
+}
+
+func TestFetchGenreCooccurrenceVisitsEveryPage(t *testing.T) {
+	t.Parallel()
+	var firstPage, secondPage tmdb.DiscoverMovie
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"page": 1,
+		"total_pages": 2,
+		"total_results": 3,
+		"results": [{"id": 1, "genre_ids": [28]}, {"id": 2, "genre_ids": [28, 29]}]
+	}`), &firstPage))
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"page": 2,
+		"total_pages": 2,
+		"total_results": 3,
+		"results": [{"id": 3, "genre_ids": [29]}]
+	}`), &secondPage))
+
+	mockClient := new(mocks.TmdbClient)
+	var nilmap map[string]string
+	mockClient.On("GetGenreMovieList", nilmap).Return(genreList, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+	}).Return(&firstPage, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"page":             "2",
+	}).Return(&secondPage, nil)
+
+	svc := NewMovieService(mockClient, new(mocks.MovieCache))
+
+	result, err := svc.FetchGenreCooccurrence(context.Background(), GenreCooccurrenceQuery{StartDate: startDate, EndDate: endDate})
+
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.Equal(t, int64(3), result.Movies)
+	assert.Equal(t, int64(2), result.Matrix[1][1].Movies)
+	mockClient.AssertNumberOfCalls(t, "GetDiscoverMovie", 2)
+}
+
+func TestFetchGenreCooccurrenceWeightedByRevenue(t *testing.T) {
+	t.Parallel()
+	action, scifi := Genre{28, "Action"}, Genre{29, "Sci-fi"}
+	mockClient := cooccurrenceClient()
+	mockCache := new(mocks.MovieCache)
+	mockCache.On("GetMovieDetails", int64(1)).Return(movieWithGenres(1, 1000, action), nil)
+	mockCache.On("GetMovieDetails", int64(2)).Return(movieWithGenres(2, 3000, action, scifi), nil)
+	mockCache.On("GetMovieDetails", int64(3)).Return(movieWithGenres(3, 0, scifi), nil)
+	mockCache.On("GetMovieDetails", int64(4)).Return(movieWithGenres(4, 4000), nil)
+
+	svc := NewMovieService(mockClient, mockCache)
+
+	result, err := svc.FetchGenreCooccurrence(context.Background(), GenreCooccurrenceQuery{
+		StartDate:       startDate,
+		EndDate:         endDate,
+		WeightByRevenue: true,
+	})
+
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.Equal(t, int64(8000), result.TotalRevenue)
+	assert.Equal(t, int64(1), result.UnknownRevenue)
+	assert.Equal(t, CooccurrenceCell{Movies: 1, Lift: 1, Revenue: 3000, RevenueLift: 2}, result.Matrix[0][1])
+	assert.Equal(t, CooccurrenceCell{Movies: 2, Lift: 2, Revenue: 4000, RevenueLift: 2}, result.Matrix[0][0])
+	mockClient.AssertNotCalled(t, "GetMovieDetails")
+}
+
+func TestValidateCooccurrenceQuery(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache))
+
+	err := svc.validateCooccurrenceQuery(GenreCooccurrenceQuery{Language: "german", StartDate: startDate})
+
+	var invalidErr *InvalidArgumentError
+	assert.True(t, errors.As(err, &invalidErr), "expected an InvalidArgumentError")
+	assert.Equal(t, []FieldViolation{
+		{Field: "language", Description: "must be an ISO 639-1 code, optionally followed by a region such as en-US"},
+		{Field: "endDate", Description: "is required"},
+	}, invalidErr.Violations)
+}
@@ -0,0 +1,136 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrGenreNotFound       = errors.New("genre not found")
+	ErrInvalidRange        = errors.New("invalid range")
+	ErrInvalidRequest      = errors.New("invalid request")
+	ErrUpstreamUnavailable = errors.New("tmdb unavailable")
+	ErrUpstreamAuth        = errors.New("tmdb rejected the api key")
+	ErrRateLimited         = errors.New("rate limited by tmdb")
+	ErrCacheUnavailable    = errors.New("cache unavailable")
+)
+
+// retry delay suggested when TMDB throttles us without saying for how long
+const defaultUpstreamRetryAfter = 10 * time.Second
+
+// FieldViolation describes why a single request field was rejected
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// InvalidArgumentError is returned for requests which can never succeed as
+// they are. Kind is the sentinel describing the problem, e.g. ErrInvalidRange.
+type InvalidArgumentError struct {
+	Kind       error
+	Violations []FieldViolation
+}
+
+func (e *InvalidArgumentError) Error() string {
+	descriptions := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		descriptions = append(descriptions, v.Field+" "+v.Description)
+	}
+
+	return fmt.Sprintf("%s: %s", e.Kind, strings.Join(descriptions, ", "))
+}
+
+func (e *InvalidArgumentError) Unwrap() error {
+	return e.Kind
+}
+
+// DependencyError is returned when TMDB or the cache fail in a recognisable
+// way. It matches Kind with errors.Is and unwraps to the original error.
+type DependencyError struct {
+	Kind       error
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *DependencyError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Kind, e.Err)
+}
+
+func (e *DependencyError) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *DependencyError) Unwrap() error {
+	return e.Err
+}
+
+// TMDB status codes, see https://www.themoviedb.org/documentation/api/status-codes
+const (
+	tmdbAuthenticationFailed = 3
+	tmdbInvalidApiKey        = 7
+	tmdbServiceOffline       = 9
+	tmdbSuspendedApiKey      = 10
+	tmdbRequestTimedOut      = 24
+	tmdbRequestOverLimit     = 25
+	tmdbInternalError        = 11
+	tmdbBackendTimeout       = 43
+	tmdbBackendOffline       = 46
+)
+
+// classifyUpstreamError wraps errors returned by TMDB into a DependencyError
+// when their cause is recognised, anything else is returned as is
+func classifyUpstreamError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var tmdbErr tmdb.Error
+	if errors.As(err, &tmdbErr) {
+		switch tmdbErr.StatusCode {
+		case tmdbAuthenticationFailed, tmdbInvalidApiKey, tmdbSuspendedApiKey:
+			return &DependencyError{Kind: ErrUpstreamAuth, Err: err}
+		case tmdbRequestOverLimit:
+			return &DependencyError{Kind: ErrRateLimited, RetryAfter: defaultUpstreamRetryAfter, Err: err}
+		case tmdbServiceOffline, tmdbInternalError, tmdbRequestTimedOut, tmdbBackendTimeout, tmdbBackendOffline:
+			return &DependencyError{Kind: ErrUpstreamUnavailable, RetryAfter: defaultUpstreamRetryAfter, Err: err}
+		}
+
+		return err
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return &DependencyError{Kind: ErrUpstreamUnavailable, Err: err}
+	}
+
+	return err
+}
+
+// classifyCacheError wraps errors returned by the cache into a DependencyError
+// when they indicate that the cache can't be reached
+func classifyCacheError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, ErrCacheClosed) || errors.Is(err, context.DeadlineExceeded) {
+		return &DependencyError{Kind: ErrCacheUnavailable, Err: err}
+	}
+
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.Unavailable, codes.DeadlineExceeded:
+			return &DependencyError{Kind: ErrCacheUnavailable, Err: err}
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClassifyUpstreamError(t *testing.T) {
+	t.Parallel()
+	someError := errors.New("some error occurred")
+
+	cases := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{"invalid api key", tmdb.Error{StatusCode: 7}, ErrUpstreamAuth},
+		{"suspended api key", tmdb.Error{StatusCode: 10}, ErrUpstreamAuth},
+		{"over limit", tmdb.Error{StatusCode: 25}, ErrRateLimited},
+		{"service offline", tmdb.Error{StatusCode: 9}, ErrUpstreamUnavailable},
+		{"network failure", &net.OpError{Op: "dial", Err: someError}, ErrUpstreamUnavailable},
+		{"unknown tmdb error", tmdb.Error{StatusCode: 34}, tmdb.Error{StatusCode: 34}},
+		{"unknown error", someError, someError},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			result := classifyUpstreamError(c.err)
+			assert.ErrorIs(t, result, c.expected)
+			assert.ErrorIs(t, result, c.err)
+		})
+	}
+}
+
+func TestClassifyCacheError(t *testing.T) {
+	t.Parallel()
+	someError := errors.New("some error occurred")
+
+	assert.ErrorIs(t, classifyCacheError(ErrCacheClosed), ErrCacheUnavailable)
+	assert.ErrorIs(t, classifyCacheError(status.Error(codes.Unavailable, "down")), ErrCacheUnavailable)
+	assert.Equal(t, someError, classifyCacheError(someError))
+}
+
+func TestInvalidArgumentError(t *testing.T) {
+	t.Parallel()
+	err := &InvalidArgumentError{
+		Kind: ErrInvalidRange,
+		Violations: []FieldViolation{
+			{Field: "startDate", Description: "must not be after endDate"},
+		},
+	}
+
+	assert.ErrorIs(t, err, ErrInvalidRange)
+	assert.Equal(t, "invalid range: startDate must not be after endDate", err.Error())
+}
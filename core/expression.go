@@ -0,0 +1,223 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antonmedv/expr"
+	"github.com/antonmedv/expr/ast"
+	"github.com/antonmedv/expr/file"
+	"github.com/antonmedv/expr/vm"
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+const (
+	defaultExpressionMaxLength = 1000
+	defaultExpressionMaxNodes  = 100
+)
+
+// expressionEnv exposes the movie details to filter expressions. Expressions
+// only see these values, they can't call methods or reach anything else.
+func expressionEnv(movie *tmdb.MovieDetails) map[string]interface{} {
+	genres := make([]string, 0, len(movie.Genres))
+	for _, g := range movie.Genres {
+		genres = append(genres, g.Name)
+	}
+
+	countries := make([]string, 0, len(movie.ProductionCountries))
+	for _, c := range movie.ProductionCountries {
+		countries = append(countries, c.Iso3166_1)
+	}
+
+	languages := make([]string, 0, len(movie.SpokenLanguages))
+	for _, l := range movie.SpokenLanguages {
+		languages = append(languages, l.Iso639_1)
+	}
+
+	return map[string]interface{}{
+		"title":                movie.Title,
+		"release_date":         movie.ReleaseDate,
+		"revenue":              int(movie.Revenue),
+		"budget":               int(movie.Budget),
+		"profit":               int(movie.Revenue - movie.Budget),
+		"runtime":              movie.Runtime,
+		"vote_average":         widenFloat32(movie.VoteAverage),
+		"vote_count":           int(movie.VoteCount),
+		"popularity":           widenFloat32(movie.Popularity),
+		"original_language":    movie.OriginalLanguage,
+		"status":               movie.Status,
+		"adult":                movie.Adult,
+		"genres":               genres,
+		"production_countries": countries,
+		"spoken_languages":     languages,
+	}
+}
+
+// ExpressionVariables lists the movie details filter expressions can refer to
+func ExpressionVariables() []string {
+	env := expressionEnv(&tmdb.MovieDetails{})
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// nodeCounter measures the size of an expression while it is compiled
+type nodeCounter struct {
+	count int
+}
+
+func (c *nodeCounter) Enter(node *ast.Node) {
+	c.count++
+}
+
+func (c *nodeCounter) Exit(node *ast.Node) {}
+
+// unknownWhenZero lists, for the variables TMDB reports as zero when it doesn't
+// know them, the variables whose zero makes them unknown
+var unknownWhenZero = map[string][]string{
+	"revenue": {"revenue"},
+	"budget":  {"budget"},
+	"profit":  {"revenue", "budget"},
+	"runtime": {"runtime"},
+}
+
+var comparisonOperators = map[string]bool{
+	"==": true, "!=": true, "<": true, ">": true, "<=": true, ">=": true, "in": true, "not in": true,
+}
+
+// unknownPatcher applies an unknown value policy to expressions: a comparison
+// involving an unknown value doesn't compare it as zero but fails under
+// UnknownExcluded and passes under UnknownIncluded
+type unknownPatcher struct {
+	policy UnknownValuePolicy
+}
+
+func (p *unknownPatcher) Enter(node *ast.Node) {}
+
+func (p *unknownPatcher) Exit(node *ast.Node) {
+	binary, ok := (*node).(*ast.BinaryNode)
+	if !ok || !comparisonOperators[binary.Operator] {
+		return
+	}
+
+	var unknown ast.Node
+	for _, name := range referencedZeroUnknowns(node) {
+		isZero := &ast.BinaryNode{Operator: "==", Left: &ast.IdentifierNode{Value: name}, Right: &ast.IntegerNode{Value: 0}}
+		if unknown == nil {
+			unknown = isZero
+		} else {
+			unknown = &ast.BinaryNode{Operator: "||", Left: unknown, Right: isZero}
+		}
+	}
+	if unknown == nil {
+		return
+	}
+
+	ast.Patch(node, &ast.ConditionalNode{
+		Cond: unknown,
+		Exp1: &ast.BoolNode{Value: p.policy == UnknownIncluded},
+		Exp2: binary,
+	})
+}
+
+// identifierCollector gathers the variables an expression refers to
+type identifierCollector struct {
+	names map[string]bool
+}
+
+func (c *identifierCollector) Enter(node *ast.Node) {}
+
+func (c *identifierCollector) Exit(node *ast.Node) {
+	if identifier, ok := (*node).(*ast.IdentifierNode); ok {
+		c.names[identifier.Value] = true
+	}
+}
+
+// referencedZeroUnknowns returns, in a stable order, the variables whose zero
+// makes a value the node refers to unknown
+func referencedZeroUnknowns(node *ast.Node) []string {
+	collector := &identifierCollector{names: map[string]bool{}}
+	ast.Walk(node, collector)
+
+	seen := map[string]bool{}
+	var names []string
+	for variable := range collector.names {
+		for _, name := range unknownWhenZero[variable] {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// compileExpression returns nil for an empty expression. Expressions are
+// limited in length and in number of nodes, which together with the memory
+// budget of the expr VM bounds how long a single evaluation can take. The
+// comparisons of unknown values follow the policy.
+func (s *MovieService) compileExpression(expression string, policy UnknownValuePolicy) (*vm.Program, error) {
+	if expression == "" {
+		return nil, nil
+	}
+
+	if len(expression) > s.expressionMaxLength {
+		return nil, fmt.Errorf("must not be longer than %d characters", s.expressionMaxLength)
+	}
+
+	counter := new(nodeCounter)
+	options := []expr.Option{
+		expr.Env(expressionEnv(&tmdb.MovieDetails{})),
+		expr.AsBool(),
+		expr.Patch(counter),
+	}
+	if policy != UnknownAsZero {
+		options = append(options, expr.Patch(&unknownPatcher{policy}))
+	}
+
+	program, err := expr.Compile(expression, options...)
+	if err != nil {
+		return nil, errors.New(describeExpressionError(err))
+	}
+
+	if counter.count > s.expressionMaxNodes {
+		return nil, fmt.Errorf("must not have more than %d operands and operators", s.expressionMaxNodes)
+	}
+
+	return program, nil
+}
+
+func describeExpressionError(err error) string {
+	var fileErr *file.Error
+	if errors.As(err, &fileErr) && !fileErr.Location.Empty() {
+		return fmt.Sprintf("%s at line %d, column %d", fileErr.Message, fileErr.Line, fileErr.Column+1)
+	}
+
+	return strings.TrimSpace(err.Error())
+}
+
+// matchesExpression evaluates a compiled expression, a nil program matches
+// every movie
+func matchesExpression(program *vm.Program, movie *tmdb.MovieDetails) (bool, error) {
+	if program == nil {
+		return true, nil
+	}
+
+	result, err := expr.Run(program, expressionEnv(movie))
+	if err != nil {
+		return false, &InvalidArgumentError{
+			Kind:       ErrInvalidRequest,
+			Violations: []FieldViolation{{Field: "expression", Description: describeExpressionError(err)}},
+		}
+	}
+
+	return result.(bool), nil
+}
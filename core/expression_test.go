@@ -0,0 +1,206 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/affanshahid/convoluted-movie-finder/core/mocks"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/stretchr/testify/assert"
+)
+
+var expressionMovie = &tmdb.MovieDetails{
+	Budget:  40000000,
+	Revenue: 100000000,
+	Runtime: 95,
+	ProductionCountries: []struct {
+		Iso3166_1 string "json:\"iso_3166_1\""
+		Name      string "json:\"name\""
+	}{
+		{"US", "United States of America"},
+	},
+	VoteAverage: 7.5,
+}
+
+func TestMatchesExpression(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache))
+
+	cases := []struct {
+		expression string
+		expected   bool
+	}{
+		{`revenue > 2 * budget && runtime < 100 && "US" in production_countries`, true},
+		{`profit > 100000000`, false},
+		{`vote_average >= 7 || adult`, true},
+		{`"FR" in production_countries`, false},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.expression, func(t *testing.T) {
+			t.Parallel()
+			program, err := svc.compileExpression(c.expression, UnknownAsZero)
+			assert.Nilf(t, err, "expected error to be nil")
+
+			matches, err := matchesExpression(program, expressionMovie)
+			assert.Nilf(t, err, "expected error to be nil")
+			assert.Equal(t, c.expected, matches)
+		})
+	}
+}
+
+func TestMatchesExpressionFloat32Values(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache))
+	rated := &tmdb.MovieDetails{VoteAverage: 7.3, Popularity: 12.3}
+
+	cases := []struct {
+		expression string
+		expected   bool
+	}{
+		{`vote_average <= 7.3`, true},
+		{`vote_average == 7.3`, true},
+		{`vote_average > 7.3`, false},
+		{`popularity != 12.3`, false},
+		{`popularity >= 12.3`, true},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.expression, func(t *testing.T) {
+			t.Parallel()
+			program, err := svc.compileExpression(c.expression, UnknownAsZero)
+			assert.Nilf(t, err, "expected error to be nil")
+
+			matches, err := matchesExpression(program, rated)
+			assert.Nilf(t, err, "expected error to be nil")
+			assert.Equal(t, c.expected, matches)
+		})
+	}
+}
+
+func TestMatchesExpressionWithoutExpression(t *testing.T) {
+	t.Parallel()
+
+	matches, err := matchesExpression(nil, expressionMovie)
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.True(t, matches)
+}
+
+func TestCompileExpressionRejectsInvalidExpressions(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache), WithExpressionLimits(60, 10))
+
+	cases := []struct {
+		name        string
+		expression  string
+		description string
+	}{
+		{"syntax error", `revenue >`, "at line 1"},
+		{"unknown variable", `gross > 10`, "unknown name gross"},
+		{"not a boolean", `revenue + 1`, "expected bool"},
+		{"too long", `revenue > 1 && budget > 1 && runtime > 1 && vote_count > 1 && adult`, "longer than 60 characters"},
+		{"too many nodes", `revenue > 1 && budget > 1 && runtime > 1 && adult`, "more than 10 operands"},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := svc.compileExpression(c.expression, UnknownAsZero)
+			assert.NotNil(t, err, "expected error to not be nil")
+			assert.Contains(t, err.Error(), c.description)
+		})
+	}
+}
+
+func TestValidateQueryReportsExpressionErrors(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache))
+
+	query := validQuery
+	query.Revenue, query.RevenueCheckOperator = 0, OpLt
+	query.Expression = `revenue >`
+
+	err := svc.ValidateQuery(query)
+
+	var invalidErr *InvalidArgumentError
+	assert.ErrorAs(t, err, &invalidErr)
+	assert.Len(t, invalidErr.Violations, 1)
+	assert.Equal(t, "expression", invalidErr.Violations[0].Field)
+}
+
+func TestQueryWithExpressionSkipsRevenueComparison(t *testing.T) {
+	t.Parallel()
+
+	query := GenrePeriodQuery{Expression: "true"}
+	assert.True(t, query.matches(expressionMovie))
+}
+
+func TestMatchesExpressionReportsEvaluationErrors(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache))
+
+	program, err := svc.compileExpression(`revenue % (runtime - 95) > 1`, UnknownAsZero)
+	assert.Nilf(t, err, "expected error to be nil")
+
+	_, err = matchesExpression(program, expressionMovie)
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+}
+
+func TestMatchesExpressionUnknownValues(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache))
+	unreleased := &tmdb.MovieDetails{Budget: 40000000, Runtime: 95, VoteAverage: 7.5}
+
+	cases := []struct {
+		expression string
+		policy     UnknownValuePolicy
+		movie      *tmdb.MovieDetails
+		expected   bool
+	}{
+		{`revenue < 1000`, UnknownAsZero, unreleased, true},
+		{`revenue < 1000`, UnknownExcluded, unreleased, false},
+		{`revenue > 1000`, UnknownIncluded, unreleased, true},
+		{`profit > 0`, UnknownIncluded, unreleased, true},
+		{`profit < 0`, UnknownExcluded, unreleased, false},
+		{`revenue + budget > 1000`, UnknownExcluded, unreleased, false},
+		{`budget > 1000 && runtime < 100`, UnknownExcluded, unreleased, true},
+		{`revenue < 1000 || vote_average > 7`, UnknownExcluded, unreleased, true},
+		{`revenue < 1000`, UnknownExcluded, expressionMovie, false},
+		{`revenue > 1000`, UnknownExcluded, expressionMovie, true},
+		{`vote_average > 7`, UnknownExcluded, unreleased, true},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(fmt.Sprintf("%s %d", c.expression, c.policy), func(t *testing.T) {
+			t.Parallel()
+			program, err := svc.compileExpression(c.expression, c.policy)
+			assert.Nilf(t, err, "expected error to be nil")
+
+			matches, err := matchesExpression(program, c.movie)
+			assert.Nilf(t, err, "expected error to be nil")
+			assert.Equal(t, c.expected, matches)
+		})
+	}
+}
+
+func TestValidateQueryAppliesUnknownValuesToExpression(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache))
+
+	query := validQuery
+	query.Revenue, query.RevenueCheckOperator = 0, OpLt
+	query.Expression = `revenue < 1000`
+	query.UnknownValues = UnknownExcluded
+
+	var v violations
+	program := svc.addFilterViolations(&v, query)
+	assert.Nilf(t, v.err(), "expected error to be nil")
+
+	matches, err := matchesExpression(program, &tmdb.MovieDetails{Budget: 40000000})
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.False(t, matches)
+}
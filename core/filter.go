@@ -0,0 +1,294 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+// MovieFilter compares a field of the movie details against Value, which must
+// be a float64, a string or a bool depending on the field. Max and the
+// exclusive flags only apply to OpBetween, whose bounds are Value and Max.
+type MovieFilter struct {
+	Field        string
+	Operator     Operator
+	Value        interface{}
+	Max          float64
+	MinExclusive bool
+	MaxExclusive bool
+
+	// unknown is the policy of the query the filter belongs to
+	unknown UnknownValuePolicy
+}
+
+// UnknownValuePolicy decides how filters treat the revenue, budget and runtime
+// TMDB reports as zero when it doesn't know them. Profit is unknown when either
+// the revenue or the budget is.
+type UnknownValuePolicy uint8
+
+const (
+	// UnknownAsZero compares unknown values as zero
+	UnknownAsZero UnknownValuePolicy = iota
+	// UnknownExcluded fails every filter on an unknown value
+	UnknownExcluded
+	// UnknownIncluded passes every filter on an unknown value
+	UnknownIncluded
+)
+
+func (p UnknownValuePolicy) IsValid() bool {
+	return p <= UnknownIncluded
+}
+
+// UnknownValueCounts counts the movies whose revenue, budget or runtime is
+// unknown
+type UnknownValueCounts struct {
+	Revenue int64
+	Budget  int64
+	Runtime int64
+}
+
+// add is safe for concurrent use
+func (c *UnknownValueCounts) add(movie *tmdb.MovieDetails) {
+	if !knownRevenue(movie) {
+		atomic.AddInt64(&c.Revenue, 1)
+	}
+	if !knownBudget(movie) {
+		atomic.AddInt64(&c.Budget, 1)
+	}
+	if !knownRuntime(movie) {
+		atomic.AddInt64(&c.Runtime, 1)
+	}
+}
+
+type fieldKind uint8
+
+const (
+	numberField fieldKind = iota
+	textField
+	boolField
+)
+
+func (k fieldKind) String() string {
+	switch k {
+	case numberField:
+		return "a number"
+	case textField:
+		return "a string"
+	default:
+		return "a boolean"
+	}
+}
+
+// movieField reads a field of the movie details, known is nil for fields TMDB
+// always knows
+type movieField struct {
+	kind  fieldKind
+	value func(movie *tmdb.MovieDetails) interface{}
+	known func(movie *tmdb.MovieDetails) bool
+}
+
+var movieFields = map[string]movieField{
+	"revenue":           {numberField, func(m *tmdb.MovieDetails) interface{} { return float64(m.Revenue) }, knownRevenue},
+	"budget":            {numberField, func(m *tmdb.MovieDetails) interface{} { return float64(m.Budget) }, knownBudget},
+	"profit":            {numberField, func(m *tmdb.MovieDetails) interface{} { return float64(m.Revenue - m.Budget) }, knownProfit},
+	"runtime":           {numberField, func(m *tmdb.MovieDetails) interface{} { return float64(m.Runtime) }, knownRuntime},
+	"vote_average":      {numberField, func(m *tmdb.MovieDetails) interface{} { return widenFloat32(m.VoteAverage) }, nil},
+	"vote_count":        {numberField, func(m *tmdb.MovieDetails) interface{} { return float64(m.VoteCount) }, nil},
+	"popularity":        {numberField, func(m *tmdb.MovieDetails) interface{} { return widenFloat32(m.Popularity) }, nil},
+	"original_language": {textField, func(m *tmdb.MovieDetails) interface{} { return m.OriginalLanguage }, nil},
+	"status":            {textField, func(m *tmdb.MovieDetails) interface{} { return m.Status }, nil},
+	"adult":             {boolField, func(m *tmdb.MovieDetails) interface{} { return m.Adult }, nil},
+}
+
+// widenFloat32 converts the float32 values of TMDB through their shortest
+// decimal form, so that a vote average of 7.3 compares equal to 7.3 rather
+// than to 7.300000190734863
+func widenFloat32(v float32) float64 {
+	widened, err := strconv.ParseFloat(strconv.FormatFloat(float64(v), 'f', -1, 32), 64)
+	if err != nil {
+		return float64(v)
+	}
+
+	return widened
+}
+
+func knownRevenue(m *tmdb.MovieDetails) bool { return m.Revenue != 0 }
+func knownBudget(m *tmdb.MovieDetails) bool  { return m.Budget != 0 }
+func knownProfit(m *tmdb.MovieDetails) bool  { return m.Revenue != 0 && m.Budget != 0 }
+func knownRuntime(m *tmdb.MovieDetails) bool { return m.Runtime != 0 }
+
+var operatorNames = map[string]Operator{
+	"lt":      OpLt,
+	"eq":      OpEq,
+	"gt":      OpGt,
+	"ge":      OpGe,
+	"le":      OpLe,
+	"ne":      OpNe,
+	"between": OpBetween,
+}
+
+// FilterFields lists the movie fields filters can refer to
+func FilterFields() []string {
+	fields := make([]string, 0, len(movieFields))
+	for name := range movieFields {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+
+	return fields
+}
+
+// ParseMovieFilter reads filters written as field:operator:value, or
+// field:between:min:max, e.g. budget:ge:1000000 or original_language:eq:en.
+// The value is read as the field's type when possible, as a string otherwise.
+func ParseMovieFilter(s string) (MovieFilter, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 3 {
+		return MovieFilter{}, fmt.Errorf("%q must be formatted as field:operator:value", s)
+	}
+
+	op, ok := operatorNames[strings.ToLower(parts[1])]
+	if !ok {
+		return MovieFilter{}, fmt.Errorf("%q has an unknown operator %q", s, parts[1])
+	}
+
+	filter := MovieFilter{Field: parts[0], Operator: op}
+	if op == OpBetween {
+		if len(parts) != 4 {
+			return MovieFilter{}, fmt.Errorf("%q must be formatted as field:between:min:max", s)
+		}
+
+		lower, lowerErr := strconv.ParseFloat(parts[2], 64)
+		upper, upperErr := strconv.ParseFloat(parts[3], 64)
+		if lowerErr != nil || upperErr != nil {
+			return MovieFilter{}, fmt.Errorf("%q must have numeric bounds", s)
+		}
+
+		filter.Value, filter.Max = lower, upper
+		return filter, nil
+	}
+
+	filter.Value = parseFilterValue(parts[0], strings.Join(parts[2:], ":"))
+
+	return filter, nil
+}
+
+// parseFilterValue reads the value as the type of the field, so that 0 and 1
+// stay numbers for number fields. Values that don't fit the field are kept as
+// strings and reported by validate.
+func parseFilterValue(field, value string) interface{} {
+	switch movieFields[field].kind {
+	case numberField:
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	case boolField:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+
+	return value
+}
+
+// validate reports the problems of the filter found at field of the request
+func (f MovieFilter) validate(v *violations, field string) {
+	movieField, ok := movieFields[f.Field]
+	if !ok {
+		v.add(ErrInvalidRequest, field+".field", fmt.Sprintf("must be one of %s", strings.Join(FilterFields(), ", ")))
+		return
+	}
+
+	if !f.Operator.IsValid() {
+		v.add(ErrInvalidRequest, field+".operator", fmt.Sprintf("unknown operator %d", f.Operator))
+		return
+	}
+
+	if f.Value == nil {
+		v.add(ErrInvalidRequest, field+".value", "is required")
+		return
+	}
+
+	if kindOf(f.Value) != movieField.kind {
+		v.add(ErrInvalidRequest, field+".value", fmt.Sprintf("must be %s to compare with %s", movieField.kind, f.Field))
+		return
+	}
+
+	switch {
+	case movieField.kind != numberField && f.Operator != OpEq && f.Operator != OpNe:
+		v.add(ErrInvalidRequest, field+".operator", fmt.Sprintf("must be eq or ne to compare with %s", f.Field))
+	case f.Operator == OpBetween:
+		lower := f.Value.(float64)
+		if f.Max < lower {
+			v.add(ErrInvalidRequest, field+".max", "must not be less than value")
+		} else if f.Max == lower && (f.MinExclusive || f.MaxExclusive) {
+			v.add(ErrInvalidRequest, field+".max", "must be greater than value when a bound is exclusive")
+		}
+	case f.Max != 0 || f.MinExclusive || f.MaxExclusive:
+		v.add(ErrInvalidRequest, field+".max", "must only be set with the between operator")
+	}
+}
+
+func kindOf(value interface{}) fieldKind {
+	switch value.(type) {
+	case float64:
+		return numberField
+	case string:
+		return textField
+	case bool:
+		return boolField
+	default:
+		// never matches a field, reported as a type mismatch
+		return fieldKind(255)
+	}
+}
+
+// matches expects a validated filter
+func (f MovieFilter) matches(movie *tmdb.MovieDetails) bool {
+	field := movieFields[f.Field]
+	if f.unknown != UnknownAsZero && field.known != nil && !field.known(movie) {
+		return f.unknown == UnknownIncluded
+	}
+
+	switch actual := field.value(movie).(type) {
+	case float64:
+		return compareNumbers(actual, f)
+	case string:
+		equal := strings.EqualFold(actual, f.Value.(string))
+		return equal == (f.Operator == OpEq)
+	case bool:
+		equal := actual == f.Value.(bool)
+		return equal == (f.Operator == OpEq)
+	default:
+		return false
+	}
+}
+
+func compareNumbers(actual float64, f MovieFilter) bool {
+	value := f.Value.(float64)
+
+	switch f.Operator {
+	case OpLt:
+		return actual < value
+	case OpEq:
+		return actual == value
+	case OpGt:
+		return actual > value
+	case OpGe:
+		return actual >= value
+	case OpLe:
+		return actual <= value
+	case OpNe:
+		return actual != value
+	case OpBetween:
+		aboveMin := actual > value || !f.MinExclusive && actual == value
+		belowMax := actual < f.Max || !f.MaxExclusive && actual == f.Max
+		return aboveMin && belowMax
+	default:
+		return false
+	}
+}
@@ -0,0 +1,209 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/affanshahid/convoluted-movie-finder/core/mocks"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/stretchr/testify/assert"
+)
+
+var filteredMovie = &tmdb.MovieDetails{
+	Adult:            false,
+	Budget:           50000000,
+	OriginalLanguage: "en",
+	Popularity:       42.5,
+	Revenue:          120000000,
+	Runtime:          95,
+	Status:           "Released",
+	VoteAverage:      7.2,
+	VoteCount:        1500,
+}
+
+func TestParseMovieFilter(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		expr     string
+		expected MovieFilter
+	}{
+		{"budget:ge:1000000", MovieFilter{Field: "budget", Operator: OpGe, Value: float64(1000000)}},
+		{"original_language:eq:en", MovieFilter{Field: "original_language", Operator: OpEq, Value: "en"}},
+		{"adult:NE:true", MovieFilter{Field: "adult", Operator: OpNe, Value: true}},
+		{"runtime:between:90:120", MovieFilter{Field: "runtime", Operator: OpBetween, Value: float64(90), Max: 120}},
+		{"runtime:gt:0", MovieFilter{Field: "runtime", Operator: OpGt, Value: float64(0)}},
+		{"revenue:eq:0", MovieFilter{Field: "revenue", Operator: OpEq, Value: float64(0)}},
+		{"vote_count:ge:1", MovieFilter{Field: "vote_count", Operator: OpGe, Value: float64(1)}},
+		{"budget:ne:0", MovieFilter{Field: "budget", Operator: OpNe, Value: float64(0)}},
+		{"adult:eq:1", MovieFilter{Field: "adult", Operator: OpEq, Value: true}},
+		{"status:eq:true", MovieFilter{Field: "status", Operator: OpEq, Value: "true"}},
+		{"runtime:gt:long", MovieFilter{Field: "runtime", Operator: OpGt, Value: "long"}},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.expr, func(t *testing.T) {
+			t.Parallel()
+			filter, err := ParseMovieFilter(c.expr)
+			assert.Nilf(t, err, "expected error to be nil")
+			assert.Equal(t, c.expected, filter)
+		})
+	}
+}
+
+func TestParseMovieFilterRejectsMalformedFilters(t *testing.T) {
+	t.Parallel()
+
+	for _, expr := range []string{"budget", "budget:about:10", "runtime:between:90", "runtime:between:a:b"} {
+		_, err := ParseMovieFilter(expr)
+		assert.NotNil(t, err, "expected error for %q", expr)
+	}
+}
+
+func TestMovieFilterMatches(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		filter   MovieFilter
+		expected bool
+	}{
+		{"revenue", MovieFilter{Field: "revenue", Operator: OpGt, Value: float64(100000000)}, true},
+		{"budget", MovieFilter{Field: "budget", Operator: OpLt, Value: float64(50000000)}, false},
+		{"profit", MovieFilter{Field: "profit", Operator: OpEq, Value: float64(70000000)}, true},
+		{"runtime", MovieFilter{Field: "runtime", Operator: OpBetween, Value: float64(90), Max: 95, MaxExclusive: true}, false},
+		{"vote average", MovieFilter{Field: "vote_average", Operator: OpGe, Value: float64(7)}, true},
+		{"vote count", MovieFilter{Field: "vote_count", Operator: OpLe, Value: float64(1000)}, false},
+		{"popularity", MovieFilter{Field: "popularity", Operator: OpNe, Value: float64(42.5)}, false},
+		{"original language", MovieFilter{Field: "original_language", Operator: OpEq, Value: "EN"}, true},
+		{"status", MovieFilter{Field: "status", Operator: OpNe, Value: "Released"}, false},
+		{"adult", MovieFilter{Field: "adult", Operator: OpEq, Value: false}, true},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, c.expected, c.filter.matches(filteredMovie))
+		})
+	}
+}
+
+func TestMovieFilterFloat32Values(t *testing.T) {
+	t.Parallel()
+	rated := &tmdb.MovieDetails{VoteAverage: 7.3, Popularity: 12.3}
+
+	cases := []struct {
+		name     string
+		filter   MovieFilter
+		expected bool
+	}{
+		{"vote average eq", MovieFilter{Field: "vote_average", Operator: OpEq, Value: 7.3}, true},
+		{"vote average ne", MovieFilter{Field: "vote_average", Operator: OpNe, Value: 7.3}, false},
+		{"vote average le", MovieFilter{Field: "vote_average", Operator: OpLe, Value: 7.3}, true},
+		{"vote average gt", MovieFilter{Field: "vote_average", Operator: OpGt, Value: 7.3}, false},
+		{"vote average between", MovieFilter{Field: "vote_average", Operator: OpBetween, Value: float64(7), Max: 7.3}, true},
+		{"vote average between exclusive", MovieFilter{Field: "vote_average", Operator: OpBetween, Value: float64(7), Max: 7.3, MaxExclusive: true}, false},
+		{"popularity eq", MovieFilter{Field: "popularity", Operator: OpEq, Value: 12.3}, true},
+		{"popularity le", MovieFilter{Field: "popularity", Operator: OpLe, Value: 12.3}, true},
+		{"popularity ne", MovieFilter{Field: "popularity", Operator: OpNe, Value: 12.3}, false},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, c.expected, c.filter.matches(rated))
+		})
+	}
+}
+
+func TestMovieFilterUnknownValues(t *testing.T) {
+	t.Parallel()
+	unreported := &tmdb.MovieDetails{Budget: 1000}
+
+	cases := []struct {
+		name     string
+		filter   MovieFilter
+		expected bool
+	}{
+		{"zero revenue is low", MovieFilter{Field: "revenue", Operator: OpLt, Value: float64(100)}, true},
+		{"unknown revenue excluded", MovieFilter{Field: "revenue", Operator: OpLt, Value: float64(100), unknown: UnknownExcluded}, false},
+		{"unknown revenue included", MovieFilter{Field: "revenue", Operator: OpGt, Value: float64(100), unknown: UnknownIncluded}, true},
+		{"unknown profit excluded", MovieFilter{Field: "profit", Operator: OpLt, Value: float64(0), unknown: UnknownExcluded}, false},
+		{"known budget", MovieFilter{Field: "budget", Operator: OpGt, Value: float64(100), unknown: UnknownExcluded}, true},
+		{"unknown runtime excluded", MovieFilter{Field: "runtime", Operator: OpLe, Value: float64(90), unknown: UnknownExcluded}, false},
+		{"always known fields", MovieFilter{Field: "vote_count", Operator: OpEq, Value: float64(0), unknown: UnknownExcluded}, true},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, c.expected, c.filter.matches(unreported))
+		})
+	}
+}
+
+func TestQueryMatchesEveryFilter(t *testing.T) {
+	t.Parallel()
+
+	query := GenrePeriodQuery{Filters: []MovieFilter{
+		{Field: "budget", Operator: OpGt, Value: float64(1000000)},
+		{Field: "original_language", Operator: OpEq, Value: "en"},
+	}}
+	assert.True(t, query.matches(filteredMovie))
+
+	query.Filters = append(query.Filters, MovieFilter{Field: "runtime", Operator: OpGt, Value: float64(100)})
+	assert.False(t, query.matches(filteredMovie))
+}
+
+func TestValidateQueryRejectsInvalidFilters(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache))
+
+	cases := []struct {
+		name   string
+		filter MovieFilter
+		field  string
+	}{
+		{"unknown field", MovieFilter{Field: "title", Operator: OpEq, Value: "Heat"}, "filters[0].field"},
+		{"unknown operator", MovieFilter{Field: "budget", Operator: 42, Value: float64(1)}, "filters[0].operator"},
+		{"missing value", MovieFilter{Field: "budget", Operator: OpGt}, "filters[0].value"},
+		{"wrong type", MovieFilter{Field: "budget", Operator: OpGt, Value: "lots"}, "filters[0].value"},
+		{"unsupported operator", MovieFilter{Field: "status", Operator: OpGt, Value: "Released"}, "filters[0].operator"},
+		{"inverted range", MovieFilter{Field: "runtime", Operator: OpBetween, Value: float64(120), Max: 90}, "filters[0].max"},
+		{"max without between", MovieFilter{Field: "runtime", Operator: OpGt, Value: float64(90), Max: 120}, "filters[0].max"},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			query := validQuery
+			query.Revenue, query.RevenueCheckOperator = 0, OpLt
+			query.Filters = []MovieFilter{c.filter}
+
+			err := svc.ValidateQuery(query)
+
+			var invalidErr *InvalidArgumentError
+			assert.ErrorAs(t, err, &invalidErr)
+			assert.Len(t, invalidErr.Violations, 1)
+			assert.Equal(t, c.field, invalidErr.Violations[0].Field)
+		})
+	}
+}
+
+func TestValidateQueryRejectsRevenueWithFilters(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache))
+
+	query := validQuery
+	query.Filters = []MovieFilter{{Field: "budget", Operator: OpGt, Value: float64(1)}}
+
+	err := svc.ValidateQuery(query)
+
+	var invalidErr *InvalidArgumentError
+	assert.ErrorAs(t, err, &invalidErr)
+	assert.Equal(t, "revenue", invalidErr.Violations[0].Field)
+}
@@ -2,9 +2,27 @@ package core
 
 import tmdb "github.com/cyruzin/golang-tmdb"
 
+// GenrePeriodDetails describes the requested genres, Id and Name are only set
+// when a single genre was requested. Pct is TotalMovies relative to
+// DenominatorMovies, the movies counted according to Denominator. Stats is nil
+// unless the query asked for it. When anomalies were detected, Anomalies flags
+// the movies of the page by TMDB id and AnomalousMovies counts the flagged
+// movies of every page. UnknownValues counts the movies of the genres whose
+// details were checked against the filters and had unknown values.
 type GenrePeriodDetails struct {
-	Id     int64
-	Name   string
-	Pct    float64
-	Movies []*tmdb.MovieDetails
+	Id                int64
+	Name              string
+	Genres            []Genre
+	ExcludedGenres    []Genre
+	Match             GenreMatch
+	Pct               float64
+	Denominator       Denominator
+	DenominatorMovies int64
+	TotalMovies       int
+	Movies            []*tmdb.MovieDetails
+	NextPageToken     string
+	Stats             *MovieStats
+	Anomalies         map[int64][]Anomaly
+	AnomalousMovies   int
+	UnknownValues     UnknownValueCounts
 }
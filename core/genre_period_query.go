@@ -0,0 +1,123 @@
+package core
+
+import (
+	"time"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+// GenreMatch decides whether movies need all of the requested genres or any of
+// them
+type GenreMatch uint8
+
+const (
+	MatchAll GenreMatch = iota
+	MatchAny
+)
+
+// Denominator decides which movies the share of the matching movies is
+// relative to
+type Denominator uint8
+
+const (
+	// every movie released in the period
+	DenominatorPeriod Denominator = iota
+	// the movies of the genres released in the period, filters or not
+	DenominatorGenre
+	// every movie released in the period which passes the filters
+	DenominatorFiltered
+)
+
+// GenrePeriodQuery selects the movies of some genres released in a period and
+// decides how they are filtered, sorted and paged.
+type GenrePeriodQuery struct {
+	// the genre, by GenreId or by GenreName, GenreIds and GenreNames add
+	// further genres combined according to GenreMatch
+	GenreId    int64
+	GenreName  string
+	GenreIds   []int64
+	GenreNames []string
+	GenreMatch GenreMatch
+
+	// movies having any of the excluded genres are left out
+	ExcludedGenreIds   []int64
+	ExcludedGenreNames []string
+
+	// applies to genre names, both when resolving them and in the result
+	Language string
+
+	// both inclusive
+	StartDate time.Time
+	EndDate   time.Time
+
+	// compares the revenue when there are no Filters nor Expression. RevenueMax
+	// and the exclusive flags only apply to OpBetween, whose bounds are
+	// inclusive by default.
+	Revenue              int64
+	RevenueMax           int64
+	RevenueMinExclusive  bool
+	RevenueMaxExclusive  bool
+	RevenueCheckOperator Operator
+
+	// movies must pass every filter and the expression, see
+	// ExpressionVariables for what expressions can refer to
+	Filters    []MovieFilter
+	Expression string
+
+	// movies are returned sorted by SortBy
+	SortBy         SortField
+	SortDescending bool
+
+	// a zero PageSize returns every movie, otherwise PageToken is the
+	// NextPageToken of the previous page
+	PageSize  int
+	PageToken string
+
+	// aggregates every matching movie, not only the page returned
+	IncludeStats bool
+
+	// defaults to every movie released in the period
+	Denominator Denominator
+
+	// looks for wrong revenues among every matching movie
+	DetectAnomalies bool
+
+	// how the filters, the revenue comparison and the comparisons of the
+	// expression treat unknown revenues, budgets and runtimes
+	UnknownValues UnknownValuePolicy
+}
+
+// filters returns the query's filters, falling back to the revenue comparison
+// when neither filters nor an expression are given
+func (q GenrePeriodQuery) filters() []MovieFilter {
+	if len(q.Filters) > 0 || q.Expression != "" {
+		filters := make([]MovieFilter, len(q.Filters))
+		for i, f := range q.Filters {
+			f.unknown = q.UnknownValues
+			filters[i] = f
+		}
+
+		return filters
+	}
+
+	return []MovieFilter{{
+		Field:        "revenue",
+		Operator:     q.RevenueCheckOperator,
+		Value:        float64(q.Revenue),
+		Max:          float64(q.RevenueMax),
+		MinExclusive: q.RevenueMinExclusive,
+		MaxExclusive: q.RevenueMaxExclusive,
+		unknown:      q.UnknownValues,
+	}}
+}
+
+// matches tells whether a movie passes every filter of a validated query
+func (q GenrePeriodQuery) matches(movie *tmdb.MovieDetails) bool {
+	for _, f := range q.filters() {
+		if !f.matches(movie) {
+			return false
+		}
+	}
+
+	return true
+}
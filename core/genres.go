@@ -0,0 +1,334 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+const defaultGenreCacheTTL = time.Hour
+
+type Genre struct {
+	Id   int64
+	Name string
+}
+
+// GenreNotFoundError is returned when a genre name can't be resolved, it
+// carries the names that come closest to the one requested
+type GenreNotFoundError struct {
+	Name        string
+	Suggestions []string
+}
+
+func (e *GenreNotFoundError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("%s: %q", ErrGenreNotFound, e.Name)
+	}
+
+	return fmt.Sprintf("%s: %q, did you mean %s?", ErrGenreNotFound, e.Name, strings.Join(e.Suggestions, ", "))
+}
+
+func (e *GenreNotFoundError) Unwrap() error {
+	return ErrGenreNotFound
+}
+
+type genreCacheEntry struct {
+	genres  []Genre
+	expires time.Time
+}
+
+// genreCache keeps the genre lists fetched from TMDB, one per language
+type genreCache struct {
+	mu      sync.Mutex
+	entries map[string]genreCacheEntry
+}
+
+func (c *genreCache) get(language string) ([]Genre, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[language]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+
+	return entry.genres, true
+}
+
+func (c *genreCache) put(language string, genres []Genre, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = map[string]genreCacheEntry{}
+	}
+
+	c.entries[language] = genreCacheEntry{genres, time.Now().Add(ttl)}
+}
+
+// ListGenres returns the TMDB movie genres with their names in the given
+// language, an empty language uses TMDB's default
+func (s *MovieService) ListGenres(ctx context.Context, language string) ([]Genre, error) {
+	// the language ends up in the genre cache, so only well formed ones may
+	// grow it
+	var v violations
+	v.addLanguage(language)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	if genres, ok := s.genres.get(language); ok {
+		return genres, nil
+	}
+
+	var urlOptions map[string]string
+	if language != "" {
+		urlOptions = map[string]string{"language": language}
+	}
+
+	result, err := s.clientFor(ctx).GetGenreMovieList(urlOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	genres := make([]Genre, 0, len(result.Genres))
+	for _, g := range result.Genres {
+		genres = append(genres, Genre{g.ID, g.Name})
+	}
+
+	if s.genreCacheTTL > 0 {
+		s.genres.put(language, genres, s.genreCacheTTL)
+	}
+
+	return genres, nil
+}
+
+// resolveGenres finds the genres a query includes and excludes, in the order
+// they were requested and without duplicates
+func (s *MovieService) resolveGenres(ctx context.Context, query GenrePeriodQuery) (included, excluded []Genre, err error) {
+	genres, err := s.ListGenres(ctx, query.Language)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []int64
+	if query.GenreId != 0 {
+		ids = append(ids, query.GenreId)
+	}
+
+	var names []string
+	if query.GenreName != "" {
+		names = append(names, query.GenreName)
+	}
+
+	included, err = lookupGenres(genres, append(ids, query.GenreIds...), append(names, query.GenreNames...))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	excluded, err = lookupGenres(genres, query.ExcludedGenreIds, query.ExcludedGenreNames)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var v violations
+	for _, g := range excluded {
+		if !containsGenre(included, g.Id) {
+			continue
+		}
+
+		// blame the names when the genre was only excluded by name
+		field := "excludedGenreNames"
+		for _, id := range query.ExcludedGenreIds {
+			if id == g.Id {
+				field = "excludedGenreIds"
+				break
+			}
+		}
+
+		v.add(ErrInvalidRequest, field, fmt.Sprintf("must not contain %s, which is also requested", g.Name))
+	}
+
+	return included, excluded, v.err()
+}
+
+func lookupGenres(genres []Genre, ids []int64, names []string) ([]Genre, error) {
+	var found []Genre
+
+	for _, id := range ids {
+		var genre *Genre
+		for i := range genres {
+			if genres[i].Id == id {
+				genre = &genres[i]
+				break
+			}
+		}
+
+		if genre == nil {
+			return nil, ErrGenreNotFound
+		}
+
+		if !containsGenre(found, genre.Id) {
+			found = append(found, *genre)
+		}
+	}
+
+	for _, name := range names {
+		genre, err := matchGenreName(genres, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !containsGenre(found, genre.Id) {
+			found = append(found, genre)
+		}
+	}
+
+	return found, nil
+}
+
+func containsGenre(genres []Genre, id int64) bool {
+	for _, g := range genres {
+		if g.Id == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// joinGenreIds formats genres the way TMDB's discover endpoint expects them, a
+// comma requiring all genres and a pipe any of them
+func joinGenreIds(genres []Genre, separator string) string {
+	ids := make([]string, 0, len(genres))
+	for _, g := range genres {
+		ids = append(ids, strconv.FormatInt(g.Id, 10))
+	}
+
+	return strings.Join(ids, separator)
+}
+
+// matchGenreName resolves a name ignoring case and punctuation, so "sci-fi"
+// matches "Sci-Fi". Failing that, an unambiguous prefix ("anim") or a single
+// closest name within a couple of typos ("thriler") is accepted.
+func matchGenreName(genres []Genre, name string) (Genre, error) {
+	wanted := normalizeGenreName(name)
+
+	var prefixMatches []Genre
+	for _, g := range genres {
+		normalized := normalizeGenreName(g.Name)
+		if normalized == wanted {
+			return g, nil
+		}
+
+		if wanted != "" && strings.HasPrefix(normalized, wanted) {
+			prefixMatches = append(prefixMatches, g)
+		}
+	}
+
+	if len(prefixMatches) == 1 {
+		return prefixMatches[0], nil
+	}
+
+	type candidate struct {
+		genre    Genre
+		distance int
+	}
+
+	candidates := make([]candidate, 0, len(genres))
+	for _, g := range genres {
+		candidates = append(candidates, candidate{g, levenshtein(wanted, normalizeGenreName(g.Name))})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	maxTypos := len([]rune(wanted)) / 4
+	if maxTypos < 1 {
+		maxTypos = 1
+	}
+
+	if len(candidates) > 0 && candidates[0].distance <= maxTypos &&
+		(len(candidates) == 1 || candidates[1].distance > candidates[0].distance) {
+		return candidates[0].genre, nil
+	}
+
+	notFound := &GenreNotFoundError{Name: name}
+	for _, g := range prefixMatches {
+		notFound.Suggestions = append(notFound.Suggestions, g.Name)
+	}
+
+	maxDistance := len([]rune(wanted))/2 + 1
+	for _, c := range candidates {
+		if len(notFound.Suggestions) >= 3 || c.distance > maxDistance {
+			break
+		}
+
+		if !containsString(notFound.Suggestions, c.genre.Name) {
+			notFound.Suggestions = append(notFound.Suggestions, c.genre.Name)
+		}
+	}
+
+	return Genre{}, notFound
+}
+
+func normalizeGenreName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func minInt(first int, rest ...int) int {
+	smallest := first
+	for _, n := range rest {
+		if n < smallest {
+			smallest = n
+		}
+	}
+
+	return smallest
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
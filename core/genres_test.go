@@ -0,0 +1,342 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/affanshahid/convoluted-movie-finder/core/mocks"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var allGenres = []Genre{
+	{28, "Action"},
+	{12, "Adventure"},
+	{16, "Animation"},
+	{35, "Comedy"},
+	{878, "Science Fiction"},
+	{53, "Thriller"},
+}
+
+func TestMatchGenreName(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		expected int64
+	}{
+		{"action", 28},
+		{"  ACTION ", 28},
+		{"science-fiction", 878},
+		{"anim", 16},
+		{"thriler", 53},
+		{"comdy", 35},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			genre, err := matchGenreName(allGenres, c.name)
+			assert.Nilf(t, err, "expected error to be nil")
+			assert.Equal(t, c.expected, genre.Id)
+		})
+	}
+}
+
+func TestMatchGenreNameSuggestsCloseNames(t *testing.T) {
+	t.Parallel()
+
+	_, err := matchGenreName(allGenres, "a")
+	assert.ErrorIs(t, err, ErrGenreNotFound)
+
+	var notFound *GenreNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.Equal(t, "a", notFound.Name)
+	assert.Equal(t, []string{"Action", "Adventure", "Animation"}, notFound.Suggestions)
+}
+
+func TestMatchGenreNameWithoutSuggestions(t *testing.T) {
+	t.Parallel()
+
+	_, err := matchGenreName(allGenres, "documentary")
+
+	var notFound *GenreNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.Empty(t, notFound.Suggestions)
+}
+
+func TestListGenresCachesPerLanguage(t *testing.T) {
+	t.Parallel()
+	mockClient := new(mocks.TmdbClient)
+	mockCache := new(mocks.MovieCache)
+	var nilmap map[string]string
+
+	mockClient.On("GetGenreMovieList", nilmap).Return(genreList, nil).Once()
+	mockClient.On("GetGenreMovieList", map[string]string{"language": "de"}).Return(&tmdb.GenreMovieList{
+		Genres: []struct {
+			ID   int64  "json:\"id\""
+			Name string "json:\"name\""
+		}{
+			{28, "Action"},
+			{35, "Komödie"},
+		},
+	}, nil).Once()
+
+	svc := NewMovieService(mockClient, mockCache)
+
+	for i := 0; i < 2; i++ {
+		genres, err := svc.ListGenres(context.Background(), "")
+		assert.Nilf(t, err, "expected error to be nil")
+		assert.Equal(t, []Genre{{28, "Action"}, {29, "Sci-fi"}}, genres)
+
+		genres, err = svc.ListGenres(context.Background(), "de")
+		assert.Nilf(t, err, "expected error to be nil")
+		assert.Equal(t, []Genre{{28, "Action"}, {35, "Komödie"}}, genres)
+	}
+
+	mockClient.AssertNumberOfCalls(t, "GetGenreMovieList", 2)
+}
+
+func TestListGenresWithoutCache(t *testing.T) {
+	t.Parallel()
+	mockClient := new(mocks.TmdbClient)
+	mockCache := new(mocks.MovieCache)
+	var nilmap map[string]string
+
+	mockClient.On("GetGenreMovieList", nilmap).Return(genreList, nil)
+
+	svc := NewMovieService(mockClient, mockCache, WithGenreCacheTTL(0))
+
+	for i := 0; i < 2; i++ {
+		_, err := svc.ListGenres(context.Background(), "")
+		assert.Nilf(t, err, "expected error to be nil")
+	}
+
+	mockClient.AssertNumberOfCalls(t, "GetGenreMovieList", 2)
+}
+
+func TestFetchGenrePeriodDetailsByGenreName(t *testing.T) {
+	t.Parallel()
+	mockClient := new(mocks.TmdbClient)
+	mockCache := new(mocks.MovieCache)
+	var nilmap map[string]string
+
+	mockCache.On("GetMovieDetails", mock.Anything).Return(nil, nil)
+	mockCache.On("SaveMovieDetails", mock.Anything).Return(nil)
+
+	mockClient.On("GetGenreMovieList", nilmap).Return(genreList, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+	}).Return(allMoviesDiscover, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"with_genres":      "29",
+	}).Return(scifiMoviesDiscover, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"with_genres":      "29",
+		"page":             "1",
+	}).Return(scifiMoviesDiscover, nil)
+	mockClient.On("GetMovieDetails", 2, nilmap).Return(someMovie1Details, nil)
+	mockClient.On("GetMovieDetails", 3, nilmap).Return(someMovie2Details, nil)
+	mockClient.On("GetMovieDetails", 4, nilmap).Return(someMovie3Details, nil)
+
+	svc := NewMovieService(mockClient, mockCache)
+
+	result, err := svc.FetchGenrePeriodDetails(context.Background(), GenrePeriodQuery{
+		GenreName:            "SCI FI",
+		StartDate:            startDate,
+		EndDate:              endDate,
+		Revenue:              1,
+		RevenueCheckOperator: OpGt,
+	})
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.Equal(t, int64(29), result.Id)
+	assert.Equal(t, "Sci-fi", result.Name)
+	assert.Equal(t, float64(75), result.Pct)
+}
+
+func TestGenreCacheExpires(t *testing.T) {
+	t.Parallel()
+	cache := new(genreCache)
+
+	cache.put("", allGenres, -time.Second)
+	_, ok := cache.get("")
+	assert.False(t, ok, "expected expired entry to be ignored")
+
+	cache.put("", allGenres, time.Minute)
+	genres, ok := cache.get("")
+	assert.True(t, ok, "expected entry to be cached")
+	assert.Equal(t, allGenres, genres)
+}
+
+func TestFetchGenrePeriodDetailsCombinesGenres(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name          string
+		match         GenreMatch
+		withGenres    string
+		excludedNames []string
+		withoutGenres string
+	}{
+		{"all", MatchAll, "28,29", nil, ""},
+		{"any", MatchAny, "28|29", nil, ""},
+		{"excluding", MatchAll, "28,29", []string{"comedy"}, "35"},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			mockClient := new(mocks.TmdbClient)
+			mockCache := new(mocks.MovieCache)
+			var nilmap map[string]string
+
+			mockCache.On("GetMovieDetails", mock.Anything).Return(nil, nil)
+			mockCache.On("SaveMovieDetails", mock.Anything).Return(nil)
+
+			discoverOptions := map[string]string{
+				"release_date.gte": startDate.Format(timeFormat),
+				"release_date.lte": endDate.Format(timeFormat),
+				"with_genres":      c.withGenres,
+			}
+			if c.withoutGenres != "" {
+				discoverOptions["without_genres"] = c.withoutGenres
+			}
+
+			pageOptions := map[string]string{"page": "1"}
+			for key, value := range discoverOptions {
+				pageOptions[key] = value
+			}
+
+			mockClient.On("GetGenreMovieList", nilmap).Return(&tmdb.GenreMovieList{
+				Genres: []struct {
+					ID   int64  "json:\"id\""
+					Name string "json:\"name\""
+				}{
+					{28, "Action"},
+					{29, "Sci-fi"},
+					{35, "Comedy"},
+				},
+			}, nil)
+			mockClient.On("GetDiscoverMovie", map[string]string{
+				"release_date.gte": startDate.Format(timeFormat),
+				"release_date.lte": endDate.Format(timeFormat),
+			}).Return(allMoviesDiscover, nil)
+			mockClient.On("GetDiscoverMovie", discoverOptions).Return(actionMoviesDiscover, nil)
+			mockClient.On("GetDiscoverMovie", pageOptions).Return(actionMoviesDiscover, nil)
+			mockClient.On("GetMovieDetails", 1, nilmap).Return(someMovieDetails, nil)
+
+			svc := NewMovieService(mockClient, mockCache)
+
+			result, err := svc.FetchGenrePeriodDetails(context.Background(), GenrePeriodQuery{
+				GenreIds:             []int64{28},
+				GenreNames:           []string{"sci-fi", "action"},
+				GenreMatch:           c.match,
+				ExcludedGenreNames:   c.excludedNames,
+				StartDate:            startDate,
+				EndDate:              endDate,
+				Revenue:              1,
+				RevenueCheckOperator: OpGt,
+			})
+			assert.Nilf(t, err, "expected error to be nil")
+			assert.Equal(t, int64(0), result.Id)
+			assert.Equal(t, []Genre{{28, "Action"}, {29, "Sci-fi"}}, result.Genres)
+			assert.Equal(t, c.match, result.Match)
+			assert.Equal(t, float64(25), result.Pct)
+			if c.excludedNames != nil {
+				assert.Equal(t, []Genre{{35, "Comedy"}}, result.ExcludedGenres)
+			}
+		})
+	}
+}
+
+func TestFetchGenrePeriodDetailsRejectsExcludedRequestedGenres(t *testing.T) {
+	t.Parallel()
+	mockClient := new(mocks.TmdbClient)
+	mockCache := new(mocks.MovieCache)
+	var nilmap map[string]string
+
+	mockClient.On("GetGenreMovieList", nilmap).Return(genreList, nil)
+
+	svc := NewMovieService(mockClient, mockCache)
+
+	_, err := svc.FetchGenrePeriodDetails(context.Background(), GenrePeriodQuery{
+		GenreIds:             []int64{28, 29},
+		ExcludedGenreNames:   []string{"action"},
+		StartDate:            startDate,
+		EndDate:              endDate,
+		RevenueCheckOperator: OpGt,
+	})
+
+	var invalidErr *InvalidArgumentError
+	assert.ErrorAs(t, err, &invalidErr)
+	assert.Equal(t, "excludedGenreNames", invalidErr.Violations[0].Field)
+	mockClient.AssertNotCalled(t, "GetDiscoverMovie", mock.Anything)
+}
+
+func TestListGenresRejectsMalformedLanguage(t *testing.T) {
+	t.Parallel()
+	mockClient := new(mocks.TmdbClient)
+	svc := NewMovieService(mockClient, new(mocks.MovieCache))
+
+	_, err := svc.ListGenres(context.Background(), "german")
+
+	var invalidErr *InvalidArgumentError
+	assert.True(t, errors.As(err, &invalidErr), "expected an InvalidArgumentError")
+	assert.Equal(t, []FieldViolation{
+		{Field: "language", Description: "must be an ISO 639-1 code, optionally followed by a region such as en-US"},
+	}, invalidErr.Violations)
+	mockClient.AssertNotCalled(t, "GetGenreMovieList")
+}
+
+func TestResolveGenresReportsConflictsPerField(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		query    GenrePeriodQuery
+		expected FieldViolation
+	}{
+		{
+			"excluded by id",
+			GenrePeriodQuery{GenreName: "action", ExcludedGenreIds: []int64{28}},
+			FieldViolation{Field: "excludedGenreIds", Description: "must not contain Action, which is also requested"},
+		},
+		{
+			"excluded by name",
+			GenrePeriodQuery{GenreNames: []string{"sci-fi"}, ExcludedGenreNames: []string{"Sci-fi"}},
+			FieldViolation{Field: "excludedGenreNames", Description: "must not contain Sci-fi, which is also requested"},
+		},
+		{
+			"excluded by id and name",
+			GenrePeriodQuery{GenreId: 28, ExcludedGenreIds: []int64{28}, ExcludedGenreNames: []string{"action"}},
+			FieldViolation{Field: "excludedGenreIds", Description: "must not contain Action, which is also requested"},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			var nilmap map[string]string
+			mockClient := new(mocks.TmdbClient)
+			mockClient.On("GetGenreMovieList", nilmap).Return(genreList, nil)
+			svc := NewMovieService(mockClient, new(mocks.MovieCache))
+
+			_, _, err := svc.resolveGenres(context.Background(), c.query)
+
+			var invalidErr *InvalidArgumentError
+			assert.True(t, errors.As(err, &invalidErr), "expected an InvalidArgumentError")
+			assert.Equal(t, []FieldViolation{c.expected}, invalidErr.Violations)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package core
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrUnknownKey = errors.New("unknown api key")
+
+// KeyStore resolves API keys to the principal they were issued to
+type KeyStore interface {
+	LookupKey(ctx context.Context, key string) (string, error)
+}
+
+// StaticKeyStore is a fixed set of API keys mapped to their principals
+type StaticKeyStore map[string]string
+
+func (s StaticKeyStore) LookupKey(ctx context.Context, key string) (string, error) {
+	principal, ok := s[key]
+	if !ok {
+		return "", ErrUnknownKey
+	}
+
+	return principal, nil
+}
+
+var _ KeyStore = StaticKeyStore(nil)
@@ -0,0 +1,147 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/affanshahid/configo"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const apiKeyPrefix = "api_key_"
+
+// KeyStoreEtcd keeps an in-memory copy of the API keys stored in etcd and
+// watches for changes, so keys can be issued and revoked without a restart.
+// Keys are stored as SHA-256 digests with the principal as the value.
+type KeyStoreEtcd struct {
+	client *clientv3.Client
+	cancel context.CancelFunc
+
+	mu   sync.RWMutex
+	keys map[string]string
+}
+
+func NewKeyStoreEtcd() (*KeyStoreEtcd, error) {
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{configo.MustGetString("etcd_url")},
+		DialTimeout: 5 * time.Second,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	s := &KeyStoreEtcd{client: client}
+
+	rev, err := s.load()
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	go s.watch(ctx, rev)
+
+	return s, nil
+}
+
+func (s *KeyStoreEtcd) LookupKey(ctx context.Context, key string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	principal, ok := s.keys[hashKey(key)]
+	if !ok {
+		return "", ErrUnknownKey
+	}
+
+	return principal, nil
+}
+
+func (s *KeyStoreEtcd) SaveKey(ctx context.Context, key string, principal string) error {
+	_, err := s.client.Put(ctx, apiKeyPrefix+hashKey(key), principal)
+	return err
+}
+
+func (s *KeyStoreEtcd) RevokeKey(ctx context.Context, key string) error {
+	_, err := s.client.Delete(ctx, apiKeyPrefix+hashKey(key))
+	return err
+}
+
+func (s *KeyStoreEtcd) Close() error {
+	s.cancel()
+	return s.client.Close()
+}
+
+func (s *KeyStoreEtcd) load() (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.client.Get(ctx, apiKeyPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return 0, err
+	}
+
+	keys := make(map[string]string, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		keys[strings.TrimPrefix(string(kv.Key), apiKeyPrefix)] = string(kv.Value)
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.mu.Unlock()
+
+	return resp.Header.Revision, nil
+}
+
+func (s *KeyStoreEtcd) watch(ctx context.Context, rev int64) {
+	for ctx.Err() == nil {
+		// every watch gets its own context so that an interrupted one is
+		// released before the next is opened
+		watchCtx, cancelWatch := context.WithCancel(ctx)
+		watchChan := s.client.Watch(watchCtx, apiKeyPrefix, clientv3.WithPrefix(), clientv3.WithRev(rev+1))
+		for resp := range watchChan {
+			if err := resp.Err(); err != nil {
+				log.Printf("api key watch failed: %v", err)
+				break
+			}
+
+			s.mu.Lock()
+			for _, ev := range resp.Events {
+				hash := strings.TrimPrefix(string(ev.Kv.Key), apiKeyPrefix)
+				if ev.Type == clientv3.EventTypeDelete {
+					delete(s.keys, hash)
+				} else {
+					s.keys[hash] = string(ev.Kv.Value)
+				}
+			}
+			s.mu.Unlock()
+			rev = resp.Header.Revision
+		}
+		cancelWatch()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		// the watch was interrupted, possibly past a compaction, so start over
+		// from a fresh snapshot
+		var err error
+		for rev, err = s.load(); err != nil && ctx.Err() == nil; rev, err = s.load() {
+			log.Printf("unable to reload api keys: %v", err)
+			time.Sleep(time.Second)
+		}
+	}
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+var _ KeyStore = (*KeyStoreEtcd)(nil)
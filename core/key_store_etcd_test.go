@@ -0,0 +1,62 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupKey(t *testing.T) {
+	setupEtcd(t)
+	defer cleanupEtcd()
+
+	store, err := NewKeyStoreEtcd()
+	assert.Nilf(t, err, "expected err to be nil")
+	defer store.Close()
+
+	err = store.SaveKey(context.Background(), "some-key", "some-team")
+	assert.Nilf(t, err, "expected err to be nil")
+
+	assert.Eventually(t, func() bool {
+		principal, err := store.LookupKey(context.Background(), "some-key")
+		return err == nil && principal == "some-team"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestLookupKeyLoadsExistingKeys(t *testing.T) {
+	setupEtcd(t)
+	defer cleanupEtcd()
+
+	_, err := client.Put(context.Background(), apiKeyPrefix+hashKey("some-key"), "some-team")
+	assert.Nilf(t, err, "expected err to be nil")
+
+	store, err := NewKeyStoreEtcd()
+	assert.Nilf(t, err, "expected err to be nil")
+	defer store.Close()
+
+	principal, err := store.LookupKey(context.Background(), "some-key")
+	assert.Nilf(t, err, "expected err to be nil")
+	assert.Equal(t, "some-team", principal)
+}
+
+func TestLookupKeyFailsAfterRevoke(t *testing.T) {
+	setupEtcd(t)
+	defer cleanupEtcd()
+
+	_, err := client.Put(context.Background(), apiKeyPrefix+hashKey("some-key"), "some-team")
+	assert.Nilf(t, err, "expected err to be nil")
+
+	store, err := NewKeyStoreEtcd()
+	assert.Nilf(t, err, "expected err to be nil")
+	defer store.Close()
+
+	err = store.RevokeKey(context.Background(), "some-key")
+	assert.Nilf(t, err, "expected err to be nil")
+
+	assert.Eventually(t, func() bool {
+		_, err := store.LookupKey(context.Background(), "some-key")
+		return err == ErrUnknownKey
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -2,9 +2,19 @@
 
 package core
 
-import tmdb "github.com/cyruzin/golang-tmdb"
+import (
+	"context"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
 
 type MovieCache interface {
 	GetMovieDetails(id int64) (*tmdb.MovieDetails, error)
 	SaveMovieDetails(movie *tmdb.MovieDetails) error
 }
+
+// Pinger is implemented by caches which can report whether their backing store
+// is reachable
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
@@ -3,7 +3,9 @@ package core
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/affanshahid/configo"
@@ -11,10 +13,19 @@ import (
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
-const moviePrefix = "movie_"
+const (
+	moviePrefix = "movie_"
+	pingKey     = "ping"
+)
+
+var ErrCacheClosed = errors.New("cache closed")
 
 type MovieCacheEtcd struct {
 	client *clientv3.Client
+
+	mu      sync.RWMutex
+	closed  bool
+	pending sync.WaitGroup
 }
 
 func NewMovieCacheEtcd() (*MovieCacheEtcd, error) {
@@ -27,7 +38,7 @@ func NewMovieCacheEtcd() (*MovieCacheEtcd, error) {
 		return nil, err
 	}
 
-	return &MovieCacheEtcd{client}, nil
+	return &MovieCacheEtcd{client: client}, nil
 }
 
 func (c *MovieCacheEtcd) GetMovieDetails(id int64) (*tmdb.MovieDetails, error) {
@@ -50,6 +61,15 @@ func (c *MovieCacheEtcd) GetMovieDetails(id int64) (*tmdb.MovieDetails, error) {
 }
 
 func (c *MovieCacheEtcd) SaveMovieDetails(movie *tmdb.MovieDetails) error {
+	c.mu.RLock()
+	if c.closed {
+		c.mu.RUnlock()
+		return ErrCacheClosed
+	}
+	c.pending.Add(1)
+	c.mu.RUnlock()
+	defer c.pending.Done()
+
 	data, err := json.Marshal(movie)
 	if err != nil {
 		return err
@@ -63,7 +83,23 @@ func (c *MovieCacheEtcd) SaveMovieDetails(movie *tmdb.MovieDetails) error {
 	return nil
 }
 
+func (c *MovieCacheEtcd) Ping(ctx context.Context) error {
+	_, err := c.client.Get(ctx, pingKey, clientv3.WithCountOnly())
+	return err
+}
+
+// Close stops accepting new writes, waits for the pending ones to be flushed
+// and closes the underlying etcd client
 func (c *MovieCacheEtcd) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	c.mu.Unlock()
+
+	c.pending.Wait()
 	return c.client.Close()
 }
 
@@ -72,3 +108,4 @@ func getMovieKey(id int64) string {
 }
 
 var _ MovieCache = (*MovieCacheEtcd)(nil)
+var _ Pinger = (*MovieCacheEtcd)(nil)
@@ -127,3 +127,28 @@ func cleanupEtcd() {
 	etcdServer.Close()
 	os.RemoveAll(etcdServer.Config().Dir)
 }
+
+func TestPing(t *testing.T) {
+	setupEtcd(t)
+	defer cleanupEtcd()
+
+	cache, err := NewMovieCacheEtcd()
+	assert.Nilf(t, err, "expected err to be nil")
+
+	err = cache.Ping(context.Background())
+	assert.Nilf(t, err, "expected err to be nil")
+}
+
+func TestSaveMovieDetailsFailsAfterClose(t *testing.T) {
+	setupEtcd(t)
+	defer cleanupEtcd()
+
+	cache, err := NewMovieCacheEtcd()
+	assert.Nilf(t, err, "expected err to be nil")
+
+	err = cache.Close()
+	assert.Nilf(t, err, "expected err to be nil")
+
+	err = cache.SaveMovieDetails(someMovie)
+	assert.Equal(t, ErrCacheClosed, err)
+}
@@ -1,10 +1,12 @@
 package core
 
 import (
-	"errors"
+	"context"
+	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/antonmedv/expr/vm"
 	tmdb "github.com/cyruzin/golang-tmdb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"golang.org/x/sync/errgroup"
@@ -12,14 +14,17 @@ import (
 
 const timeFormat = "2006-01-02"
 
-var ErrGenreNotFound = errors.New("genre not found")
-
 type Operator uint8
 
 const (
 	OpLt Operator = iota
 	OpEq
 	OpGt
+	OpGe
+	OpLe
+	OpNe
+	// OpBetween compares revenue against the range from Revenue to RevenueMax
+	OpBetween
 )
 
 type multiMovieDetailsMsg struct {
@@ -32,68 +37,240 @@ type movieDetailsMsg struct {
 	ack   chan bool
 }
 
-type totalMsg struct {
-	total int64
-	err   error
+type MovieService struct {
+	client        TmdbClient
+	cache         MovieCache
+	maxPeriodSpan time.Duration
+	genreCacheTTL time.Duration
+	genres        *genreCache
+	slots         chan struct{}
+
+	expressionMaxLength int
+	expressionMaxNodes  int
 }
 
-type MovieService struct {
-	client TmdbClient
-	cache  MovieCache
+type ServiceOption func(*MovieService)
+
+// WithMaxPeriodSpan rejects queries whose period is longer than span, a zero
+// span means no limit
+func WithMaxPeriodSpan(span time.Duration) ServiceOption {
+	return func(s *MovieService) {
+		s.maxPeriodSpan = span
+	}
+}
+
+// WithGenreCacheTTL sets how long genre lists are kept in memory, a zero ttl
+// disables caching
+func WithGenreCacheTTL(ttl time.Duration) ServiceOption {
+	return func(s *MovieService) {
+		s.genreCacheTTL = ttl
+	}
+}
+
+// WithExpressionLimits bounds the size of filter expressions, in characters and
+// in operands and operators
+func WithExpressionLimits(maxLength, maxNodes int) ServiceOption {
+	return func(s *MovieService) {
+		s.expressionMaxLength = maxLength
+		s.expressionMaxNodes = maxNodes
+	}
+}
+
+// WithMaxConcurrency bounds the number of TMDB calls in flight across all
+// requests, zero means no limit
+func WithMaxConcurrency(calls int) ServiceOption {
+	return func(s *MovieService) {
+		s.slots = nil
+		if calls > 0 {
+			s.slots = make(chan struct{}, calls)
+		}
+	}
+}
+
+func NewMovieService(client TmdbClient, cache MovieCache, opts ...ServiceOption) *MovieService {
+	s := &MovieService{
+		client:        client,
+		cache:         cache,
+		genreCacheTTL: defaultGenreCacheTTL,
+		genres:        new(genreCache),
+
+		expressionMaxLength: defaultExpressionMaxLength,
+		expressionMaxNodes:  defaultExpressionMaxNodes,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
-func NewMovieService(client TmdbClient, cache MovieCache) *MovieService {
-	return &MovieService{client, cache}
+// CheckDependencies verifies that the cache is reachable and that TMDB accepts
+// the configured API key by issuing the cheapest available call
+func (s *MovieService) CheckDependencies(ctx context.Context) error {
+	if pinger, ok := s.cache.(Pinger); ok {
+		if err := pinger.Ping(ctx); err != nil {
+			return fmt.Errorf("cache: %w", classifyCacheError(err))
+		}
+	}
+
+	if _, err := s.clientFor(ctx).GetGenreMovieList(nil); err != nil {
+		return fmt.Errorf("tmdb: %w", err)
+	}
+
+	return nil
 }
 
 func (s *MovieService) FetchGenrePeriodDetailsWithRevenueFilter(
+	ctx context.Context,
 	genreId int64,
 	startDate time.Time,
 	endDate time.Time,
 	revenue int64,
 	revenueCheckOperator Operator,
 ) (GenrePeriodDetails, error) {
+	return s.FetchGenrePeriodDetails(ctx, GenrePeriodQuery{
+		GenreId:              genreId,
+		StartDate:            startDate,
+		EndDate:              endDate,
+		Revenue:              revenue,
+		RevenueCheckOperator: revenueCheckOperator,
+	})
+}
+
+func (s *MovieService) FetchGenrePeriodDetails(ctx context.Context, query GenrePeriodQuery) (GenrePeriodDetails, error) {
 	var genreDetails GenrePeriodDetails
-	genreDetails.Id = genreId
+	genreDetails.Id = query.GenreId
 
-	genreResult, err := s.client.GetGenreMovieList(nil)
+	expression, err := s.validateQuery(query)
 	if err != nil {
 		return genreDetails, err
 	}
 
-	found := false
-	for _, g := range genreResult.Genres {
-		if g.ID == genreId {
-			genreDetails.Name = g.Name
-			found = true
-			break
+	included, excluded, err := s.resolveGenres(ctx, query)
+	if err != nil {
+		return genreDetails, err
+	}
+
+	return s.fetchGenrePeriodDetails(ctx, query, included, excluded, expression)
+}
+
+// fetchGenrePeriodDetails runs a validated query whose genres were resolved
+func (s *MovieService) fetchGenrePeriodDetails(
+	ctx context.Context,
+	query GenrePeriodQuery,
+	included, excluded []Genre,
+	expression *vm.Program,
+) (GenrePeriodDetails, error) {
+	var genreDetails GenrePeriodDetails
+	var err error
+
+	genreDetails.Genres = included
+	genreDetails.ExcludedGenres = excluded
+	genreDetails.Match = query.GenreMatch
+	if len(included) == 1 {
+		genreDetails.Id = included[0].Id
+		genreDetails.Name = included[0].Name
+	} else {
+		genreDetails.Id = 0
+	}
+
+	var total int64
+	if periodPlan := filteredPeriodPlan(query, expression); query.Denominator == DenominatorFiltered && periodPlan.checksDetails() {
+		// the details of every movie of the period are needed to count the
+		// denominator, the movies of the genres are picked from the same walk
+		// rather than fetched a second time
+		inGenres := genreMatcher(query.GenreMatch, included, excluded)
+		periodPlan.unknowns = &genreDetails.UnknownValues
+		periodPlan.counted = inGenres
+
+		err = s.streamMovies(ctx, periodPlan, func(movie *tmdb.MovieDetails) {
+			total++
+			if inGenres(movie) {
+				genreDetails.Movies = append(genreDetails.Movies, movie)
+			}
+		})
+		if err != nil {
+			return genreDetails, err
+		}
+	} else {
+		plan := planQuery(query.filters(), genreDiscoverOptions(query, included, excluded), expression)
+		plan.unknowns = &genreDetails.UnknownValues
+
+		// the denominator is counted alongside, a failure of either stops
+		// the other
+		eg, egCtx := errgroup.WithContext(ctx)
+		eg.Go(func() error {
+			var err error
+			total, err = s.countDenominator(egCtx, query, included, excluded, expression)
+			return err
+		})
+		eg.Go(func() error {
+			var err error
+			genreDetails.Movies, err = s.fetchMovies(egCtx, plan)
+			return err
+		})
+
+		if err := eg.Wait(); err != nil {
+			return genreDetails, err
 		}
 	}
 
-	if !found {
-		return genreDetails, ErrGenreNotFound
+	genreDetails.Denominator = query.Denominator
+	genreDetails.DenominatorMovies = total
+	if total > 0 {
+		genreDetails.Pct = (float64(len(genreDetails.Movies)) / float64(total)) * 100
+	}
+	genreDetails.TotalMovies = len(genreDetails.Movies)
+	if query.IncludeStats {
+		genreDetails.Stats = computeStats(genreDetails.Movies)
 	}
 
-	totalMsgChan := make(chan totalMsg)
+	var anomalies map[int64][]Anomaly
+	if query.DetectAnomalies {
+		anomalies = detectAnomalies(genreDetails.Movies)
+		genreDetails.AnomalousMovies = len(anomalies)
+	}
 
-	go func() {
-		total, err := s.getTotalMoviesInPeriod(startDate, endDate)
-		totalMsgChan <- totalMsg{total, err}
-	}()
+	genreDetails.Movies, genreDetails.NextPageToken, err = paginate(query, genreDetails.Movies)
+	if err != nil {
+		return genreDetails, err
+	}
 
-	result, err := s.client.GetDiscoverMovie(map[string]string{
-		"release_date.gte": startDate.Format(timeFormat),
-		"release_date.lte": endDate.Format(timeFormat),
-		"with_genres":      strconv.FormatInt(genreId, 10),
+	if query.DetectAnomalies {
+		genreDetails.Anomalies = map[int64][]Anomaly{}
+		for _, movie := range genreDetails.Movies {
+			if flags, ok := anomalies[movie.ID]; ok {
+				genreDetails.Anomalies[movie.ID] = flags
+			}
+		}
+	}
+
+	return genreDetails, nil
+}
+
+// fetchMovies lists the movies the plan selects and returns the details of
+// those which match, in no particular order
+func (s *MovieService) fetchMovies(ctx context.Context, plan *queryPlan) (movies []*tmdb.MovieDetails, err error) {
+	err = s.streamMovies(ctx, plan, func(movie *tmdb.MovieDetails) {
+		movies = append(movies, movie)
 	})
 
+	return movies, err
+}
+
+// streamMovies calls visit with the details of every matching movie as soon as
+// its page is fetched, one movie at a time, so that callers only keep what
+// they need
+func (s *MovieService) streamMovies(ctx context.Context, plan *queryPlan, visit func(movie *tmdb.MovieDetails)) error {
+	result, err := s.clientFor(ctx).GetDiscoverMovie(plan.discoverOptions)
+
 	if err != nil {
-		return genreDetails, err
+		return err
 	}
 
 	totalPages := result.TotalPages
 
-	var eg errgroup.Group
+	eg, egCtx := errgroup.WithContext(ctx)
 	moviesChan := make(chan multiMovieDetailsMsg, 10)
 
 	for i := int64(1); i <= totalPages; i++ {
@@ -101,12 +278,9 @@ func (s *MovieService) FetchGenrePeriodDetailsWithRevenueFilter(
 		ackChannel := make(chan bool)
 		eg.Go(func() error {
 			movies, err := s.getMovieDetailsFromPage(
-				genreId,
-				startDate,
-				endDate,
+				egCtx,
+				plan,
 				page,
-				revenue,
-				revenueCheckOperator,
 			)
 
 			if err != nil {
@@ -121,48 +295,42 @@ func (s *MovieService) FetchGenrePeriodDetailsWithRevenueFilter(
 	}
 
 	go func() {
-		for {
-			msg := <-moviesChan
-			genreDetails.Movies = append(genreDetails.Movies, msg.movies...)
-			msg.ack <- true
-		}
+		err = eg.Wait()
+		close(moviesChan)
 	}()
 
-	totalResult := <-totalMsgChan
-	if totalResult.err != nil {
-		return genreDetails, totalResult.err
-	}
-
-	err = eg.Wait()
-	if err != nil {
-		return genreDetails, err
+	for msg := range moviesChan {
+		for _, movie := range msg.movies {
+			visit(movie)
+		}
+		msg.ack <- true
 	}
 
-	genreDetails.Pct = (float64(len(genreDetails.Movies)) / float64(totalResult.total)) * 100
-
-	return genreDetails, nil
+	return err
 }
 
 func (s *MovieService) getMovieDetailsFromPage(
-	genreId int64,
-	start, end time.Time,
+	ctx context.Context,
+	plan *queryPlan,
 	page int64,
-	revenue int64,
-	revenueCheckOperator Operator,
 ) (ret []*tmdb.MovieDetails, err error) {
-	result, err := s.client.GetDiscoverMovie(map[string]string{
-		"release_date.gte": start.Format(timeFormat),
-		"release_date.lte": end.Format(timeFormat),
-		"with_genres":      strconv.FormatInt(genreId, 10),
-		"page":             strconv.FormatInt(page, 10),
-	})
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	pageOptions := map[string]string{"page": strconv.FormatInt(page, 10)}
+	for key, value := range plan.discoverOptions {
+		pageOptions[key] = value
+	}
+
+	result, err := s.clientFor(ctx).GetDiscoverMovie(pageOptions)
 
 	if err != nil {
 		return nil, err
 	}
 
 	movieChan := make(chan movieDetailsMsg, 10)
-	var eg errgroup.Group
+	eg, ctx := errgroup.WithContext(ctx)
 
 	for _, movie := range result.Results {
 		lMovie := movie
@@ -171,29 +339,33 @@ func (s *MovieService) getMovieDetailsFromPage(
 			var result *tmdb.MovieDetails
 			cachedMovie, err := s.cache.GetMovieDetails(lMovie.ID)
 			if err != nil && !isConnectivityError(err) {
-				return err
+				return classifyCacheError(err)
 			}
 
 			if cachedMovie == nil && err == nil {
-				result, err = s.client.GetMovieDetails(int(lMovie.ID), nil)
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+
+				result, err = s.clientFor(ctx).GetMovieDetails(int(lMovie.ID), nil)
 				if err != nil {
 					return err
 				}
 
 				err = s.cache.SaveMovieDetails(result)
 				if err != nil && !isConnectivityError(err) {
-					return err
+					return classifyCacheError(err)
 				}
 			} else {
 				result = cachedMovie
 			}
 
-			switch {
-			case revenueCheckOperator == OpGt && result.Revenue > revenue:
-				fallthrough
-			case revenueCheckOperator == OpLt && result.Revenue < revenue:
-				fallthrough
-			case revenueCheckOperator == OpEq && result.Revenue == revenue:
+			matches, err := plan.matches(result)
+			if err != nil {
+				return err
+			}
+
+			if matches {
 				movieChan <- movieDetailsMsg{result, ack}
 				<-ack
 			}
@@ -203,20 +375,105 @@ func (s *MovieService) getMovieDetailsFromPage(
 	}
 
 	go func() {
-		for {
-			msg := <-movieChan
-			ret = append(ret, msg.movie)
-			msg.ack <- true
-		}
+		err = eg.Wait()
+		close(movieChan)
 	}()
 
-	err = eg.Wait()
+	for msg := range movieChan {
+		ret = append(ret, msg.movie)
+		msg.ack <- true
+	}
 
 	return ret, err
 }
 
-func (s *MovieService) getTotalMoviesInPeriod(start, end time.Time) (int64, error) {
-	result, err := s.client.GetDiscoverMovie(map[string]string{
+// filteredPeriodPlan selects every movie released in the query's period which
+// passes its filters
+func filteredPeriodPlan(query GenrePeriodQuery, expression *vm.Program) *queryPlan {
+	return planQuery(query.filters(), map[string]string{
+		"release_date.gte": query.StartDate.Format(timeFormat),
+		"release_date.lte": query.EndDate.Format(timeFormat),
+	}, expression)
+}
+
+// genreMatcher tells whether the details of a movie have the genres a query
+// requests, the way genreDiscoverOptions selects them
+func genreMatcher(match GenreMatch, included, excluded []Genre) func(movie *tmdb.MovieDetails) bool {
+	return func(movie *tmdb.MovieDetails) bool {
+		found := 0
+		for _, g := range movie.Genres {
+			if containsGenre(excluded, g.ID) {
+				return false
+			}
+			if containsGenre(included, g.ID) {
+				found++
+			}
+		}
+
+		if match == MatchAny {
+			return found > 0
+		}
+
+		return found == len(included)
+	}
+}
+
+// genreDiscoverOptions selects the movies released in the query's period that
+// match its genres
+func genreDiscoverOptions(query GenrePeriodQuery, included, excluded []Genre) map[string]string {
+	separator := ","
+	if query.GenreMatch == MatchAny {
+		separator = "|"
+	}
+
+	options := map[string]string{
+		"release_date.gte": query.StartDate.Format(timeFormat),
+		"release_date.lte": query.EndDate.Format(timeFormat),
+		"with_genres":      joinGenreIds(included, separator),
+	}
+
+	if len(excluded) > 0 {
+		options["without_genres"] = joinGenreIds(excluded, ",")
+	}
+
+	return options
+}
+
+// countDenominator counts the movies Pct is relative to with a single TMDB
+// call. It only counts filtered movies whose filters are pushed down exactly,
+// fetchGenrePeriodDetails walks the period itself for the others.
+func (s *MovieService) countDenominator(
+	ctx context.Context,
+	query GenrePeriodQuery,
+	included, excluded []Genre,
+	expression *vm.Program,
+) (int64, error) {
+	switch query.Denominator {
+	case DenominatorGenre:
+		result, err := s.clientFor(ctx).GetDiscoverMovie(genreDiscoverOptions(query, included, excluded))
+		if err != nil {
+			return 0, err
+		}
+
+		return result.TotalResults, nil
+	case DenominatorFiltered:
+		result, err := s.clientFor(ctx).GetDiscoverMovie(filteredPeriodPlan(query, expression).discoverOptions)
+		if err != nil {
+			return 0, err
+		}
+
+		return result.TotalResults, nil
+	default:
+		return s.getTotalMoviesInPeriod(ctx, query.StartDate, query.EndDate)
+	}
+}
+
+func (s *MovieService) getTotalMoviesInPeriod(ctx context.Context, start, end time.Time) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	result, err := s.clientFor(ctx).GetDiscoverMovie(map[string]string{
 		"release_date.gte": start.Format(timeFormat),
 		"release_date.lte": end.Format(timeFormat),
 	})
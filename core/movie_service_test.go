@@ -1,7 +1,9 @@
 package core
 
 import (
+	"context"
 	"errors"
+	"runtime"
 	"testing"
 	"time"
 
@@ -190,15 +192,19 @@ func TestFetchGenrePeriodDetailsWithRevenueFilter(t *testing.T) {
 	mockClient.On("GetMovieDetails", 1, nilmap).Return(someMovieDetails, nil)
 
 	expected := GenrePeriodDetails{
-		Id:     28,
-		Name:   "Action",
-		Pct:    25,
-		Movies: []*tmdb.MovieDetails{someMovieDetails},
+		Id:                28,
+		Name:              "Action",
+		Genres:            []Genre{{28, "Action"}},
+		Pct:               25,
+		DenominatorMovies: 4,
+		TotalMovies:       1,
+		Movies:            []*tmdb.MovieDetails{someMovieDetails},
+		UnknownValues:     UnknownValueCounts{Budget: 1, Runtime: 1},
 	}
 
 	svc := NewMovieService(mockClient, mockCache)
 
-	result, err := svc.FetchGenrePeriodDetailsWithRevenueFilter(expected.Id, startDate, endDate, 1, OpGt)
+	result, err := svc.FetchGenrePeriodDetailsWithRevenueFilter(context.Background(), expected.Id, startDate, endDate, 1, OpGt)
 	assert.Nilf(t, err, "expected error to be nil")
 	assert.Equal(t, expected, result)
 }
@@ -216,7 +222,7 @@ func TestFetchGenrePeriodDetailsWithRevenueFilterErrorsWithInvalidGenre(t *testi
 
 	svc := NewMovieService(mockClient, mockCache)
 
-	_, err := svc.FetchGenrePeriodDetailsWithRevenueFilter(21, startDate, endDate, 1, OpGt)
+	_, err := svc.FetchGenrePeriodDetailsWithRevenueFilter(context.Background(), 21, startDate, endDate, 1, OpGt)
 	assert.NotNil(t, err, "expected error to not be nil")
 	assert.Equal(t, ErrGenreNotFound, err)
 }
@@ -252,7 +258,7 @@ func TestFetchGenrePeriodDetailsWithRevenueFilterErrorsWhenTotalNotAvailable(t *
 
 	svc := NewMovieService(mockClient, mockCache)
 
-	_, err := svc.FetchGenrePeriodDetailsWithRevenueFilter(28, startDate, endDate, 1, OpGt)
+	_, err := svc.FetchGenrePeriodDetailsWithRevenueFilter(context.Background(), 28, startDate, endDate, 1, OpGt)
 	assert.NotNil(t, err, "expected error to not be nil")
 	assert.Equal(t, expectedError, err)
 }
@@ -290,7 +296,7 @@ func TestFetchGenrePeriodDetailsWithRevenueFilterErrorsWhenMovieDetailsNotAvaila
 
 	svc := NewMovieService(mockClient, mockCache)
 
-	_, err := svc.FetchGenrePeriodDetailsWithRevenueFilter(29, startDate, endDate, 1, OpGt)
+	_, err := svc.FetchGenrePeriodDetailsWithRevenueFilter(context.Background(), 29, startDate, endDate, 1, OpGt)
 	assert.NotNil(t, err, "expected error to not be nil")
 	assert.Equal(t, expectedError, err)
 }
@@ -324,15 +330,19 @@ func TestFetchGenrePeriodDetailsWithRevenueFilterUsingCache(t *testing.T) {
 	mockClient.On("GetMovieDetails", 1, nilmap).Return(someMovieDetails, nil)
 
 	expected := GenrePeriodDetails{
-		Id:     28,
-		Name:   "Action",
-		Pct:    25,
-		Movies: []*tmdb.MovieDetails{cachedMovieDetails},
+		Id:                28,
+		Name:              "Action",
+		Genres:            []Genre{{28, "Action"}},
+		Pct:               25,
+		DenominatorMovies: 4,
+		TotalMovies:       1,
+		Movies:            []*tmdb.MovieDetails{cachedMovieDetails},
+		UnknownValues:     UnknownValueCounts{Budget: 1, Runtime: 1},
 	}
 
 	svc := NewMovieService(mockClient, mockCache)
 
-	result, err := svc.FetchGenrePeriodDetailsWithRevenueFilter(expected.Id, startDate, endDate, 9999, OpGt)
+	result, err := svc.FetchGenrePeriodDetailsWithRevenueFilter(context.Background(), expected.Id, startDate, endDate, 9999, OpGt)
 	assert.Nilf(t, err, "expected error to be nil")
 	assert.Equal(t, expected, result)
 }
@@ -366,15 +376,19 @@ func TestFetchGenrePeriodDetailsWithRevenueFilterUpdatesCache(t *testing.T) {
 	mockClient.On("GetMovieDetails", 1, nilmap).Return(someMovieDetails, nil)
 
 	expected := GenrePeriodDetails{
-		Id:     28,
-		Name:   "Action",
-		Pct:    25,
-		Movies: []*tmdb.MovieDetails{someMovieDetails},
+		Id:                28,
+		Name:              "Action",
+		Genres:            []Genre{{28, "Action"}},
+		Pct:               25,
+		DenominatorMovies: 4,
+		TotalMovies:       1,
+		Movies:            []*tmdb.MovieDetails{someMovieDetails},
+		UnknownValues:     UnknownValueCounts{Budget: 1, Runtime: 1},
 	}
 
 	svc := NewMovieService(mockClient, mockCache)
 
-	result, err := svc.FetchGenrePeriodDetailsWithRevenueFilter(expected.Id, startDate, endDate, 1, OpGt)
+	result, err := svc.FetchGenrePeriodDetailsWithRevenueFilter(context.Background(), expected.Id, startDate, endDate, 1, OpGt)
 	assert.Nilf(t, err, "expected error to be nil")
 	assert.Equal(t, expected, result)
 	mockCache.AssertCalled(t, "SaveMovieDetails", someMovieDetails)
@@ -413,7 +427,412 @@ func TestFetchGenrePeriodDetailsWithRevenueFilterErrorsWhenCacheGetFails(t *test
 
 	svc := NewMovieService(mockClient, mockCache)
 
-	_, err := svc.FetchGenrePeriodDetailsWithRevenueFilter(29, startDate, endDate, 1, OpGt)
+	_, err := svc.FetchGenrePeriodDetailsWithRevenueFilter(context.Background(), 29, startDate, endDate, 1, OpGt)
 	assert.NotNil(t, err, "expected error to not be nil")
 	assert.Equal(t, expectedError, err)
 }
+
+func TestCheckDependencies(t *testing.T) {
+	t.Parallel()
+	mockClient := new(mocks.TmdbClient)
+	mockCache := new(mocks.MovieCache)
+	var nilmap map[string]string
+
+	mockClient.On("GetGenreMovieList", nilmap).Return(genreList, nil)
+
+	svc := NewMovieService(mockClient, mockCache)
+
+	err := svc.CheckDependencies(context.Background())
+	assert.Nilf(t, err, "expected error to be nil")
+}
+
+func TestCheckDependenciesErrorsWhenTmdbFails(t *testing.T) {
+	t.Parallel()
+	mockClient := new(mocks.TmdbClient)
+	mockCache := new(mocks.MovieCache)
+	var nilmap map[string]string
+
+	expectedError := tmdb.Error{StatusCode: 7, StatusMessage: "Invalid API key"}
+
+	mockClient.On("GetGenreMovieList", nilmap).Return(nil, expectedError)
+
+	svc := NewMovieService(mockClient, mockCache)
+
+	err := svc.CheckDependencies(context.Background())
+	assert.NotNil(t, err, "expected error to not be nil")
+	assert.ErrorIs(t, err, expectedError)
+}
+
+func TestFetchGenrePeriodDetailsWithRevenueFilterStopsWhenCancelled(t *testing.T) {
+	t.Parallel()
+	mockClient := new(mocks.TmdbClient)
+	mockCache := new(mocks.MovieCache)
+	var nilmap map[string]string
+
+	mockCache.On("GetMovieDetails", mock.Anything).Return(nil, nil)
+	mockCache.On("SaveMovieDetails", mock.Anything).Return(nil)
+
+	mockClient.On("GetGenreMovieList", nilmap).Return(genreList, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"with_genres":      "28",
+	}).Return(actionMoviesDiscover, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	svc := NewMovieService(mockClient, mockCache)
+
+	_, err := svc.FetchGenrePeriodDetailsWithRevenueFilter(ctx, 28, startDate, endDate, 1, OpGt)
+	assert.Equal(t, context.Canceled, err)
+	mockClient.AssertNotCalled(t, "GetMovieDetails", mock.Anything, mock.Anything)
+}
+
+func TestFetchGenrePeriodDetailsWithRevenueFilterRecordsUsage(t *testing.T) {
+	t.Parallel()
+	mockClient := new(mocks.TmdbClient)
+	mockCache := new(mocks.MovieCache)
+
+	var nilmap map[string]string
+
+	mockCache.On("GetMovieDetails", mock.Anything).Return(nil, nil)
+	mockCache.On("SaveMovieDetails", mock.Anything).Return(nil)
+
+	mockClient.On("GetGenreMovieList", nilmap).Return(genreList, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+	}).Return(allMoviesDiscover, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"with_genres":      "28",
+	}).Return(actionMoviesDiscover, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"with_genres":      "28",
+		"page":             "1",
+	}).Return(actionMoviesDiscover, nil)
+	mockClient.On("GetMovieDetails", 1, nilmap).Return(someMovieDetails, nil)
+
+	svc := NewMovieService(mockClient, mockCache)
+
+	usage := new(Usage)
+	_, err := svc.FetchGenrePeriodDetailsWithRevenueFilter(WithUsage(context.Background(), usage), 28, startDate, endDate, 1, OpGt)
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.Equal(t, int64(5), usage.TmdbCalls())
+}
+
+func TestFetchGenrePeriodDetailsWithRevenueFilterErrorsWithInvalidRange(t *testing.T) {
+	t.Parallel()
+	mockClient := new(mocks.TmdbClient)
+	mockCache := new(mocks.MovieCache)
+
+	svc := NewMovieService(mockClient, mockCache)
+
+	_, err := svc.FetchGenrePeriodDetailsWithRevenueFilter(context.Background(), 28, endDate, startDate, 1, OpGt)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+	mockClient.AssertNotCalled(t, "GetGenreMovieList", mock.Anything)
+}
+
+func TestFetchGenrePeriodDetailsWithRevenueFilterClassifiesUpstreamErrors(t *testing.T) {
+	t.Parallel()
+	mockClient := new(mocks.TmdbClient)
+	mockCache := new(mocks.MovieCache)
+	var nilmap map[string]string
+
+	mockClient.On("GetGenreMovieList", nilmap).Return(nil, tmdb.Error{StatusCode: 7})
+
+	svc := NewMovieService(mockClient, mockCache)
+
+	_, err := svc.FetchGenrePeriodDetailsWithRevenueFilter(context.Background(), 28, startDate, endDate, 1, OpGt)
+	assert.ErrorIs(t, err, ErrUpstreamAuth)
+}
+
+func TestMatchesRevenue(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		query    GenrePeriodQuery
+		revenue  int64
+		expected bool
+	}{
+		{"lt", GenrePeriodQuery{RevenueCheckOperator: OpLt, Revenue: 10}, 10, false},
+		{"eq", GenrePeriodQuery{RevenueCheckOperator: OpEq, Revenue: 10}, 10, true},
+		{"gt", GenrePeriodQuery{RevenueCheckOperator: OpGt, Revenue: 10}, 10, false},
+		{"ge", GenrePeriodQuery{RevenueCheckOperator: OpGe, Revenue: 10}, 10, true},
+		{"le", GenrePeriodQuery{RevenueCheckOperator: OpLe, Revenue: 10}, 11, false},
+		{"ne", GenrePeriodQuery{RevenueCheckOperator: OpNe, Revenue: 10}, 11, true},
+		{"between", GenrePeriodQuery{RevenueCheckOperator: OpBetween, Revenue: 10, RevenueMax: 20}, 15, true},
+		{"between inclusive min", GenrePeriodQuery{RevenueCheckOperator: OpBetween, Revenue: 10, RevenueMax: 20}, 10, true},
+		{"between inclusive max", GenrePeriodQuery{RevenueCheckOperator: OpBetween, Revenue: 10, RevenueMax: 20}, 20, true},
+		{"between exclusive min", GenrePeriodQuery{RevenueCheckOperator: OpBetween, Revenue: 10, RevenueMax: 20, RevenueMinExclusive: true}, 10, false},
+		{"between exclusive max", GenrePeriodQuery{RevenueCheckOperator: OpBetween, Revenue: 10, RevenueMax: 20, RevenueMaxExclusive: true}, 20, false},
+		{"between outside", GenrePeriodQuery{RevenueCheckOperator: OpBetween, Revenue: 10, RevenueMax: 20}, 21, false},
+		{"unknown", GenrePeriodQuery{RevenueCheckOperator: 42}, 0, false},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, c.expected, c.query.matches(&tmdb.MovieDetails{Revenue: c.revenue}))
+		})
+	}
+}
+
+func TestFetchGenrePeriodDetailsDenominators(t *testing.T) {
+	t.Parallel()
+
+	periodOptions := map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+	}
+	actionOptions := map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"with_genres":      "28",
+	}
+	actionPageOptions := map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"with_genres":      "28",
+		"page":             "1",
+	}
+
+	cases := []struct {
+		name        string
+		denominator Denominator
+		filters     []MovieFilter
+		setup       func(client *mocks.TmdbClient)
+		expected    int64
+		pct         float64
+	}{
+		{
+			name:        "movies of the genre",
+			denominator: DenominatorGenre,
+			setup: func(client *mocks.TmdbClient) {
+				client.On("GetDiscoverMovie", actionOptions).Return(actionMoviesDiscover, nil)
+				client.On("GetDiscoverMovie", actionPageOptions).Return(actionMoviesDiscover, nil)
+			},
+			expected: 1,
+			pct:      100,
+		},
+		{
+			name:        "filtered movies counted by tmdb",
+			denominator: DenominatorFiltered,
+			filters:     []MovieFilter{{Field: "vote_count", Operator: OpGe, Value: float64(10)}},
+			setup: func(client *mocks.TmdbClient) {
+				client.On("GetDiscoverMovie", map[string]string{
+					"release_date.gte": startDate.Format(timeFormat),
+					"release_date.lte": endDate.Format(timeFormat),
+					"vote_count.gte":   "10",
+				}).Return(allMoviesDiscover, nil)
+				client.On("GetDiscoverMovie", map[string]string{
+					"release_date.gte": startDate.Format(timeFormat),
+					"release_date.lte": endDate.Format(timeFormat),
+					"with_genres":      "28",
+					"vote_count.gte":   "10",
+				}).Return(actionMoviesDiscover, nil)
+				client.On("GetDiscoverMovie", map[string]string{
+					"release_date.gte": startDate.Format(timeFormat),
+					"release_date.lte": endDate.Format(timeFormat),
+					"with_genres":      "28",
+					"vote_count.gte":   "10",
+					"page":             "1",
+				}).Return(actionMoviesDiscover, nil)
+			},
+			expected: 4,
+			pct:      25,
+		},
+		{
+			name:        "filtered movies checked on their details",
+			denominator: DenominatorFiltered,
+			filters:     []MovieFilter{{Field: "revenue", Operator: OpGt, Value: float64(1)}},
+			setup: func(client *mocks.TmdbClient) {
+				client.On("GetDiscoverMovie", periodOptions).Return(allMoviesDiscover, nil)
+				client.On("GetDiscoverMovie", map[string]string{
+					"release_date.gte": startDate.Format(timeFormat),
+					"release_date.lte": endDate.Format(timeFormat),
+					"page":             "1",
+				}).Return(allMoviesDiscover, nil)
+				client.On("GetMovieDetails", 1, map[string]string(nil)).Return(movieWithGenres(1, 1000, Genre{28, "Action"}), nil)
+			},
+			expected: 3,
+			pct:      100.0 / 3,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			mockClient := new(mocks.TmdbClient)
+			mockCache := new(mocks.MovieCache)
+
+			var nilmap map[string]string
+			mockCache.On("GetMovieDetails", mock.Anything).Return(nil, nil)
+			mockCache.On("SaveMovieDetails", mock.Anything).Return(nil)
+			mockClient.On("GetGenreMovieList", nilmap).Return(genreList, nil)
+			c.setup(mockClient)
+			mockClient.On("GetMovieDetails", 1, nilmap).Return(someMovieDetails, nil)
+			mockClient.On("GetMovieDetails", 2, nilmap).Return(someMovie1Details, nil)
+			mockClient.On("GetMovieDetails", 3, nilmap).Return(someMovie2Details, nil)
+			mockClient.On("GetMovieDetails", 4, nilmap).Return(&tmdb.MovieDetails{ID: 4}, nil)
+
+			svc := NewMovieService(mockClient, mockCache)
+
+			query := GenrePeriodQuery{
+				GenreId:     28,
+				StartDate:   startDate,
+				EndDate:     endDate,
+				Filters:     c.filters,
+				Denominator: c.denominator,
+			}
+			if c.filters == nil {
+				query.Revenue, query.RevenueCheckOperator = 1, OpGt
+			}
+
+			result, err := svc.FetchGenrePeriodDetails(context.Background(), query)
+
+			assert.Nilf(t, err, "expected error to be nil")
+			assert.Equal(t, c.denominator, result.Denominator)
+			assert.Equal(t, c.expected, result.DenominatorMovies)
+			assert.InDelta(t, c.pct, result.Pct, 1e-9)
+		})
+	}
+}
+
+func TestFetchGenrePeriodDetailsFilteredDenominatorFetchesDetailsOnce(t *testing.T) {
+	t.Parallel()
+	action, scifi := Genre{28, "Action"}, Genre{29, "Sci-fi"}
+
+	cases := []struct {
+		name     string
+		query    GenrePeriodQuery
+		expected []int64
+		unknowns UnknownValueCounts
+	}{
+		{"all genres", GenrePeriodQuery{GenreIds: []int64{28, 29}}, []int64{2}, UnknownValueCounts{Budget: 1, Runtime: 1}},
+		{"any genre", GenrePeriodQuery{GenreIds: []int64{28, 29}, GenreMatch: MatchAny}, []int64{1, 2, 3}, UnknownValueCounts{Budget: 3, Runtime: 3}},
+		{"excluded genre", GenrePeriodQuery{GenreId: 28, ExcludedGenreIds: []int64{29}}, []int64{1}, UnknownValueCounts{Budget: 1, Runtime: 1}},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			mockClient := new(mocks.TmdbClient)
+			mockCache := new(mocks.MovieCache)
+
+			var nilmap map[string]string
+			mockCache.On("GetMovieDetails", mock.Anything).Return(nil, nil)
+			mockCache.On("SaveMovieDetails", mock.Anything).Return(nil)
+			mockClient.On("GetGenreMovieList", nilmap).Return(genreList, nil)
+			mockClient.On("GetDiscoverMovie", map[string]string{
+				"release_date.gte": startDate.Format(timeFormat),
+				"release_date.lte": endDate.Format(timeFormat),
+			}).Return(allMoviesDiscover, nil)
+			mockClient.On("GetDiscoverMovie", map[string]string{
+				"release_date.gte": startDate.Format(timeFormat),
+				"release_date.lte": endDate.Format(timeFormat),
+				"page":             "1",
+			}).Return(allMoviesDiscover, nil)
+			mockClient.On("GetMovieDetails", 1, nilmap).Return(movieWithGenres(1, 1000, action), nil).Once()
+			mockClient.On("GetMovieDetails", 2, nilmap).Return(movieWithGenres(2, 2000, action, scifi), nil).Once()
+			mockClient.On("GetMovieDetails", 3, nilmap).Return(movieWithGenres(3, 3000, scifi), nil).Once()
+			mockClient.On("GetMovieDetails", 4, nilmap).Return(movieWithGenres(4, 4000), nil).Once()
+
+			svc := NewMovieService(mockClient, mockCache)
+
+			query := c.query
+			query.StartDate, query.EndDate = startDate, endDate
+			query.Filters = []MovieFilter{{Field: "revenue", Operator: OpGt, Value: float64(0)}}
+			query.Denominator = DenominatorFiltered
+
+			result, err := svc.FetchGenrePeriodDetails(context.Background(), query)
+
+			assert.Nilf(t, err, "expected error to be nil")
+			assert.Equal(t, c.expected, movieIds(result.Movies))
+			assert.Equal(t, int64(4), result.DenominatorMovies)
+			assert.Equal(t, c.unknowns, result.UnknownValues)
+			mockClient.AssertNumberOfCalls(t, "GetMovieDetails", 4)
+			mockClient.AssertNumberOfCalls(t, "GetDiscoverMovie", 2)
+		})
+	}
+}
+
+func TestStreamMoviesLeavesNoGoroutineBehind(t *testing.T) {
+	mockClient := new(mocks.TmdbClient)
+	mockCache := new(mocks.MovieCache)
+
+	var nilmap map[string]string
+
+	mockCache.On("GetMovieDetails", mock.Anything).Return(nil, nil)
+	mockCache.On("SaveMovieDetails", mock.Anything).Return(nil)
+
+	mockClient.On("GetDiscoverMovie", map[string]string{"with_genres": "28"}).Return(actionMoviesDiscover, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{"with_genres": "28", "page": "1"}).Return(actionMoviesDiscover, nil)
+	mockClient.On("GetMovieDetails", 1, nilmap).Return(someMovieDetails, nil)
+
+	svc := NewMovieService(mockClient, mockCache)
+	before := runtime.NumGoroutine()
+
+	var visited []*tmdb.MovieDetails
+	err := svc.streamMovies(context.Background(), planQuery(nil, map[string]string{"with_genres": "28"}, nil), func(movie *tmdb.MovieDetails) {
+		visited = append(visited, movie)
+	})
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.Equal(t, []*tmdb.MovieDetails{someMovieDetails}, visited)
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), before)
+}
+
+func TestFetchGenrePeriodDetailsStopsFetchingMoviesWhenTotalFails(t *testing.T) {
+	t.Parallel()
+	mockClient := new(mocks.TmdbClient)
+	mockCache := new(mocks.MovieCache)
+	var nilmap map[string]string
+
+	mockCache.On("GetMovieDetails", mock.Anything).Return(nil, nil)
+	mockCache.On("SaveMovieDetails", mock.Anything).Return(nil)
+
+	expectedError := errors.New("some error occurred")
+
+	mockClient.On("GetGenreMovieList", nilmap).Return(genreList, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+	}).Return(nil, expectedError)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"with_genres":      "28",
+	}).After(50*time.Millisecond).Return(actionMoviesDiscover, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"with_genres":      "28",
+		"page":             "1",
+	}).Return(actionMoviesDiscover, nil)
+	mockClient.On("GetMovieDetails", 1, nilmap).Return(someMovieDetails, nil)
+
+	svc := NewMovieService(mockClient, mockCache)
+
+	_, err := svc.FetchGenrePeriodDetailsWithRevenueFilter(context.Background(), 28, startDate, endDate, 1, OpGt)
+	assert.ErrorIs(t, err, expectedError)
+	mockClient.AssertNotCalled(t, "GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"with_genres":      "28",
+		"page":             "1",
+	})
+	mockClient.AssertNotCalled(t, "GetMovieDetails", 1, nilmap)
+}
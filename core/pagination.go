@@ -0,0 +1,148 @@
+package core
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+const maxPageSize = 1000
+
+// SortField orders the movies of a result, ties are broken by TMDB id so that
+// the order is the same on every request
+type SortField uint8
+
+const (
+	SortById SortField = iota
+	SortByRevenue
+	SortByReleaseDate
+	SortByTitle
+	SortByVoteAverage
+	SortByProfit
+)
+
+func (f SortField) IsValid() bool {
+	return f <= SortByProfit
+}
+
+// sortKey holds the value a movie is sorted by, text for titles and release
+// dates and a number otherwise
+type sortKey struct {
+	Number float64 `json:"n,omitempty"`
+	Text   string  `json:"t,omitempty"`
+}
+
+func sortKeyOf(field SortField, movie *tmdb.MovieDetails) sortKey {
+	switch field {
+	case SortByRevenue:
+		return sortKey{Number: float64(movie.Revenue)}
+	case SortByReleaseDate:
+		return sortKey{Text: movie.ReleaseDate}
+	case SortByTitle:
+		return sortKey{Text: strings.ToLower(movie.Title)}
+	case SortByVoteAverage:
+		return sortKey{Number: float64(movie.VoteAverage)}
+	case SortByProfit:
+		return sortKey{Number: float64(movie.Revenue - movie.Budget)}
+	default:
+		return sortKey{Number: float64(movie.ID)}
+	}
+}
+
+// pageCursor is the position after the last movie of a page. It is tied to the
+// query it was issued for, so that it can't be used to page through another.
+type pageCursor struct {
+	Key   sortKey `json:"k"`
+	Id    int64   `json:"i"`
+	Query uint64  `json:"q"`
+}
+
+// before tells whether a movie sorts before the given position
+func before(query GenrePeriodQuery, key sortKey, id int64, position sortKey, positionId int64) bool {
+	if key != position {
+		less := key.Number < position.Number || key.Number == position.Number && key.Text < position.Text
+		return less != query.SortDescending
+	}
+
+	return id < positionId
+}
+
+// queryFingerprint identifies a query regardless of the page requested and of
+// whether stats are included
+func queryFingerprint(query GenrePeriodQuery) uint64 {
+	query.PageSize = 0
+	query.PageToken = ""
+	query.IncludeStats = false
+	query.DetectAnomalies = false
+
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%+v", query)
+	return h.Sum64()
+}
+
+func encodePageToken(cursor pageCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(query GenrePeriodQuery) (*pageCursor, error) {
+	if query.PageToken == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(query.PageToken)
+	if err != nil {
+		return nil, errors.New("is malformed")
+	}
+
+	var cursor pageCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, errors.New("is malformed")
+	}
+
+	if cursor.Query != queryFingerprint(query) {
+		return nil, errors.New("was issued for a different query")
+	}
+
+	return &cursor, nil
+}
+
+// paginate sorts the movies and keeps the page the query asks for, returning
+// the token of the next page if there is one
+func paginate(query GenrePeriodQuery, movies []*tmdb.MovieDetails) ([]*tmdb.MovieDetails, string, error) {
+	sort.Slice(movies, func(i, j int) bool {
+		return before(query, sortKeyOf(query.SortBy, movies[i]), movies[i].ID, sortKeyOf(query.SortBy, movies[j]), movies[j].ID)
+	})
+
+	cursor, err := decodePageToken(query)
+	if err != nil {
+		return nil, "", err
+	}
+
+	start := 0
+	if cursor != nil {
+		start = sort.Search(len(movies), func(i int) bool {
+			return before(query, cursor.Key, cursor.Id, sortKeyOf(query.SortBy, movies[i]), movies[i].ID)
+		})
+	}
+
+	if query.PageSize == 0 || start+query.PageSize >= len(movies) {
+		return movies[start:], "", nil
+	}
+
+	page := movies[start : start+query.PageSize]
+	last := page[len(page)-1]
+	next := encodePageToken(pageCursor{
+		Key:   sortKeyOf(query.SortBy, last),
+		Id:    last.ID,
+		Query: queryFingerprint(query),
+	})
+
+	return page, next, nil
+}
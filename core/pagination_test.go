@@ -0,0 +1,128 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/affanshahid/convoluted-movie-finder/core/mocks"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func paginationMovies() []*tmdb.MovieDetails {
+	return []*tmdb.MovieDetails{
+		{ID: 4, Title: "delta", Revenue: 300, Budget: 100, ReleaseDate: "2021-04-01", VoteAverage: 5},
+		{ID: 2, Title: "Bravo", Revenue: 100, Budget: 200, ReleaseDate: "2021-02-01", VoteAverage: 8},
+		{ID: 5, Title: "echo", Revenue: 300, Budget: 0, ReleaseDate: "2021-05-01", VoteAverage: 6},
+		{ID: 1, Title: "alpha", Revenue: 200, Budget: 50, ReleaseDate: "2021-01-01", VoteAverage: 7},
+		{ID: 3, Title: "Charlie", Revenue: 100, Budget: 10, ReleaseDate: "2021-03-01", VoteAverage: 9},
+	}
+}
+
+func movieIds(movies []*tmdb.MovieDetails) []int64 {
+	ids := make([]int64, 0, len(movies))
+	for _, m := range movies {
+		ids = append(ids, m.ID)
+	}
+
+	return ids
+}
+
+func TestPaginateSorts(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		field    SortField
+		desc     bool
+		expected []int64
+	}{
+		{"id", SortById, false, []int64{1, 2, 3, 4, 5}},
+		{"revenue", SortByRevenue, false, []int64{2, 3, 1, 4, 5}},
+		{"revenue descending", SortByRevenue, true, []int64{4, 5, 1, 2, 3}},
+		{"release date", SortByReleaseDate, true, []int64{5, 4, 3, 2, 1}},
+		{"title", SortByTitle, false, []int64{1, 2, 3, 4, 5}},
+		{"vote average", SortByVoteAverage, true, []int64{3, 2, 1, 5, 4}},
+		{"profit", SortByProfit, false, []int64{2, 3, 1, 4, 5}},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			movies, next, err := paginate(GenrePeriodQuery{SortBy: c.field, SortDescending: c.desc}, paginationMovies())
+			assert.Nilf(t, err, "expected error to be nil")
+			assert.Empty(t, next)
+			assert.Equal(t, c.expected, movieIds(movies))
+		})
+	}
+}
+
+func TestPaginateWalksPages(t *testing.T) {
+	t.Parallel()
+	query := GenrePeriodQuery{GenreId: 28, SortBy: SortByRevenue, SortDescending: true, PageSize: 2}
+
+	var pages [][]int64
+	for {
+		movies, next, err := paginate(query, paginationMovies())
+		assert.Nilf(t, err, "expected error to be nil")
+		pages = append(pages, movieIds(movies))
+
+		if next == "" {
+			break
+		}
+		query.PageToken = next
+	}
+
+	assert.Equal(t, [][]int64{{4, 5}, {1, 2}, {3}}, pages)
+}
+
+func TestPaginateResumesAfterChanges(t *testing.T) {
+	t.Parallel()
+	query := GenrePeriodQuery{SortBy: SortByRevenue, PageSize: 2}
+
+	_, next, err := paginate(query, paginationMovies())
+	assert.Nilf(t, err, "expected error to be nil")
+
+	// a movie sorting before the cursor appears between requests
+	movies := append(paginationMovies(), &tmdb.MovieDetails{ID: 6, Revenue: 50})
+	query.PageToken = next
+
+	page, _, err := paginate(query, movies)
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.Equal(t, []int64{1, 4}, movieIds(page))
+}
+
+func TestValidateQueryRejectsInvalidPagination(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache))
+
+	_, tokenForOtherQuery, err := paginate(GenrePeriodQuery{GenreId: 12, PageSize: 1}, paginationMovies())
+	assert.Nilf(t, err, "expected error to be nil")
+
+	cases := []struct {
+		name        string
+		modify      func(q *GenrePeriodQuery)
+		field       string
+		description string
+	}{
+		{"unknown sort field", func(q *GenrePeriodQuery) { q.SortBy = 42 }, "sortBy", "unknown sort field 42"},
+		{"negative page size", func(q *GenrePeriodQuery) { q.PageSize = -1 }, "pageSize", "must be between 0 and 1000"},
+		{"malformed token", func(q *GenrePeriodQuery) { q.PageToken = "!!" }, "pageToken", "is malformed"},
+		{"foreign token", func(q *GenrePeriodQuery) { q.PageToken = tokenForOtherQuery }, "pageToken", "was issued for a different query"},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			query := validQuery
+			c.modify(&query)
+
+			err := svc.ValidateQuery(query)
+
+			var invalidErr *InvalidArgumentError
+			assert.ErrorAs(t, err, &invalidErr)
+			assert.Equal(t, []FieldViolation{{Field: c.field, Description: c.description}}, invalidErr.Violations)
+		})
+	}
+}
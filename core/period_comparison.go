@@ -0,0 +1,156 @@
+package core
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/antonmedv/expr/vm"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"golang.org/x/sync/errgroup"
+)
+
+// PeriodComparisonQuery compares the period of a genre query with a baseline
+// period, e.g. a quarter with the same quarter of the previous year. Paging
+// doesn't apply and both sides always include stats.
+type PeriodComparisonQuery struct {
+	GenrePeriodQuery
+	BaselineStartDate time.Time
+	BaselineEndDate   time.Time
+}
+
+// PeriodComparison holds both sides of a comparison, the deltas from Baseline
+// to Current and the ids of the movies found on one side only, in ascending
+// order
+type PeriodComparison struct {
+	Current        GenrePeriodDetails
+	Baseline       GenrePeriodDetails
+	Deltas         PeriodDeltas
+	OnlyInCurrent  []int64
+	OnlyInBaseline []int64
+}
+
+// PeriodDeltas compares the movie counts, the shares and the revenue
+// aggregates of both sides
+type PeriodDeltas struct {
+	Movies        Delta
+	Pct           Delta
+	TotalRevenue  Delta
+	MeanRevenue   Delta
+	MedianRevenue Delta
+	P90Revenue    Delta
+	ROI           Delta
+}
+
+// Delta is the change of a value from the baseline. Relative is the change
+// relative to the baseline value, 0.5 meaning 50%, and is nil when the
+// baseline value is zero.
+type Delta struct {
+	Absolute float64
+	Relative *float64
+}
+
+// ComparePeriods resolves the genres once and fetches both periods
+// concurrently
+func (s *MovieService) ComparePeriods(ctx context.Context, query PeriodComparisonQuery) (PeriodComparison, error) {
+	var comparison PeriodComparison
+
+	expression, err := s.validatePeriodComparisonQuery(query)
+	if err != nil {
+		return comparison, err
+	}
+
+	included, excluded, err := s.resolveGenres(ctx, query.GenrePeriodQuery)
+	if err != nil {
+		return comparison, err
+	}
+
+	current := query.GenrePeriodQuery
+	current.PageSize, current.PageToken = 0, ""
+	current.IncludeStats = true
+
+	baseline := current
+	baseline.StartDate, baseline.EndDate = query.BaselineStartDate, query.BaselineEndDate
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		var err error
+		comparison.Current, err = s.fetchGenrePeriodDetails(egCtx, current, included, excluded, expression)
+		return err
+	})
+	eg.Go(func() error {
+		var err error
+		comparison.Baseline, err = s.fetchGenrePeriodDetails(egCtx, baseline, included, excluded, expression)
+		return err
+	})
+
+	if err := eg.Wait(); err != nil {
+		return comparison, err
+	}
+
+	comparison.Deltas = periodDeltas(comparison.Current, comparison.Baseline)
+	comparison.OnlyInCurrent = moviesOnlyIn(comparison.Current.Movies, comparison.Baseline.Movies)
+	comparison.OnlyInBaseline = moviesOnlyIn(comparison.Baseline.Movies, comparison.Current.Movies)
+
+	return comparison, nil
+}
+
+func (s *MovieService) validatePeriodComparisonQuery(query PeriodComparisonQuery) (*vm.Program, error) {
+	expression, err := s.validateQuery(query.GenrePeriodQuery)
+
+	v, err := violationsOf(err)
+	if err != nil {
+		return nil, err
+	}
+
+	s.addNamedPeriodViolations(&v, "baselineStartDate", "baselineEndDate", query.BaselineStartDate, query.BaselineEndDate)
+
+	return expression, v.err()
+}
+
+func periodDeltas(current, baseline GenrePeriodDetails) PeriodDeltas {
+	deltas := PeriodDeltas{
+		Movies: delta(float64(current.TotalMovies), float64(baseline.TotalMovies)),
+		Pct:    delta(current.Pct, baseline.Pct),
+	}
+
+	if current.Stats != nil && baseline.Stats != nil {
+		deltas.TotalRevenue = delta(float64(current.Stats.TotalRevenue), float64(baseline.Stats.TotalRevenue))
+		deltas.MeanRevenue = delta(current.Stats.MeanRevenue, baseline.Stats.MeanRevenue)
+		deltas.MedianRevenue = delta(current.Stats.MedianRevenue, baseline.Stats.MedianRevenue)
+		deltas.P90Revenue = delta(current.Stats.P90Revenue, baseline.Stats.P90Revenue)
+		deltas.ROI = delta(current.Stats.ROI, baseline.Stats.ROI)
+	}
+
+	return deltas
+}
+
+func delta(current, baseline float64) Delta {
+	d := Delta{Absolute: current - baseline}
+	if baseline != 0 {
+		relative := d.Absolute / math.Abs(baseline)
+		d.Relative = &relative
+	}
+
+	return d
+}
+
+// moviesOnlyIn returns the ids of the movies of side missing from other
+func moviesOnlyIn(side, other []*tmdb.MovieDetails) []int64 {
+	seen := make(map[int64]bool, len(other))
+	for _, movie := range other {
+		seen[movie.ID] = true
+	}
+
+	var ids []int64
+	for _, movie := range side {
+		if !seen[movie.ID] {
+			ids = append(ids, movie.ID)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
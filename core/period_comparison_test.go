@@ -0,0 +1,101 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/affanshahid/convoluted-movie-finder/core/mocks"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestComparePeriods(t *testing.T) {
+	t.Parallel()
+	mockClient := new(mocks.TmdbClient)
+	mockCache := new(mocks.MovieCache)
+
+	var nilmap map[string]string
+
+	mockCache.On("GetMovieDetails", mock.Anything).Return(nil, nil)
+	mockCache.On("SaveMovieDetails", mock.Anything).Return(nil)
+
+	mockClient.On("GetGenreMovieList", nilmap).Return(genreList, nil)
+	for _, period := range []struct {
+		start, end string
+		discover   *tmdb.DiscoverMovie
+	}{
+		{"2021-01-01", "2021-12-31", allMoviesDiscover},
+		{"2020-01-01", "2020-12-31", scifiMoviesDiscover},
+	} {
+		mockClient.On("GetDiscoverMovie", map[string]string{
+			"release_date.gte": period.start,
+			"release_date.lte": period.end,
+		}).Return(allMoviesDiscover, nil)
+		mockClient.On("GetDiscoverMovie", map[string]string{
+			"release_date.gte": period.start,
+			"release_date.lte": period.end,
+			"with_genres":      "28",
+		}).Return(period.discover, nil)
+		mockClient.On("GetDiscoverMovie", map[string]string{
+			"release_date.gte": period.start,
+			"release_date.lte": period.end,
+			"with_genres":      "28",
+			"page":             "1",
+		}).Return(period.discover, nil)
+	}
+	mockClient.On("GetMovieDetails", 1, nilmap).Return(&tmdb.MovieDetails{ID: 1, Revenue: 1000, Budget: 500}, nil)
+	mockClient.On("GetMovieDetails", 2, nilmap).Return(&tmdb.MovieDetails{ID: 2, Revenue: 2000, Budget: 500}, nil)
+	mockClient.On("GetMovieDetails", 3, nilmap).Return(&tmdb.MovieDetails{ID: 3}, nil)
+	mockClient.On("GetMovieDetails", 4, nilmap).Return(&tmdb.MovieDetails{ID: 4, Revenue: 3000, Budget: 500}, nil)
+
+	svc := NewMovieService(mockClient, mockCache)
+
+	result, err := svc.ComparePeriods(context.Background(), PeriodComparisonQuery{
+		GenrePeriodQuery: GenrePeriodQuery{
+			GenreId:   28,
+			StartDate: startDate,
+			EndDate:   endDate,
+			Filters:   []MovieFilter{{Field: "revenue", Operator: OpGt, Value: float64(0)}},
+			PageSize:  1,
+		},
+		BaselineStartDate: date(2020, 1, 1),
+		BaselineEndDate:   date(2020, 12, 31),
+	})
+
+	relative := func(value float64) *float64 { return &value }
+
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.Equal(t, []int64{1, 2, 4}, movieIds(result.Current.Movies))
+	assert.Equal(t, []int64{2, 4}, movieIds(result.Baseline.Movies))
+	assert.NotNil(t, result.Current.Stats)
+	assert.Equal(t, Delta{Absolute: 1, Relative: relative(0.5)}, result.Deltas.Movies)
+	assert.Equal(t, Delta{Absolute: 25, Relative: relative(0.5)}, result.Deltas.Pct)
+	assert.Equal(t, Delta{Absolute: 1000, Relative: relative(0.2)}, result.Deltas.TotalRevenue)
+	assert.Equal(t, []int64{1}, result.OnlyInCurrent)
+	assert.Empty(t, result.OnlyInBaseline)
+}
+
+func TestDeltaWithoutBaseline(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, Delta{Absolute: 5}, delta(5, 0))
+}
+
+func TestValidatePeriodComparisonQuery(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache))
+
+	_, err := svc.validatePeriodComparisonQuery(PeriodComparisonQuery{
+		GenrePeriodQuery:  GenrePeriodQuery{GenreId: 28, StartDate: startDate, EndDate: endDate, RevenueCheckOperator: OpGt},
+		BaselineStartDate: endDate,
+		BaselineEndDate:   startDate,
+	})
+
+	var invalidErr *InvalidArgumentError
+	assert.True(t, errors.As(err, &invalidErr), "expected an InvalidArgumentError")
+	assert.Equal(t, ErrInvalidRange, invalidErr.Kind)
+	assert.Equal(t, []FieldViolation{
+		{Field: "baselineStartDate", Description: "must not be after baselineEndDate"},
+	}, invalidErr.Violations)
+}
@@ -0,0 +1,158 @@
+package core
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/antonmedv/expr/vm"
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+// queryPlan describes how a query is run: which movies are listed through
+// TMDB's discover endpoint and what is left to check on their details
+type queryPlan struct {
+	discoverOptions map[string]string
+	filters         []MovieFilter
+	expression      *vm.Program
+
+	// unknowns counts the unknown values of every movie checked, when set,
+	// or only of those counted selects when that is set too
+	unknowns *UnknownValueCounts
+	counted  func(movie *tmdb.MovieDetails) bool
+}
+
+// discoverRange maps a numeric field onto the discover options bounding it
+type discoverRange struct {
+	gte, lte string
+	integer  bool
+}
+
+var discoverRanges = map[string]discoverRange{
+	"vote_average": {"vote_average.gte", "vote_average.lte", false},
+	"vote_count":   {"vote_count.gte", "vote_count.lte", true},
+	"runtime":      {"with_runtime.gte", "with_runtime.lte", true},
+}
+
+// planQuery pushes down the filters TMDB can apply while listing movies, so
+// that details are only fetched for movies which may match. Filters which
+// TMDB can only approximate are pushed down as a wider range and checked
+// again on the details.
+func planQuery(filters []MovieFilter, discoverOptions map[string]string, expression *vm.Program) *queryPlan {
+	plan := &queryPlan{discoverOptions: discoverOptions, expression: expression}
+
+	gte := map[string]float64{}
+	lte := map[string]float64{}
+	for _, f := range filters {
+		if f.Field == "original_language" && f.Operator == OpEq {
+			if _, ok := plan.discoverOptions["with_original_language"]; !ok {
+				plan.discoverOptions["with_original_language"] = strings.ToLower(f.Value.(string))
+				continue
+			}
+		}
+
+		// TMDB compares unknown values as zero, movies whose value is unknown
+		// can't be kept by a pushed down bound
+		unknownMatters := f.unknown != UnknownAsZero && movieFields[f.Field].known != nil
+
+		r, ok := discoverRanges[f.Field]
+		if !ok || f.Operator == OpNe || unknownMatters && f.unknown == UnknownIncluded {
+			plan.filters = append(plan.filters, f)
+			continue
+		}
+
+		low, high, exact := f.bounds(r.integer)
+		exact = exact && !unknownMatters
+		if !math.IsInf(low, -1) {
+			if current, ok := gte[r.gte]; !ok || low > current {
+				gte[r.gte] = low
+			}
+		}
+		if !math.IsInf(high, 1) {
+			if current, ok := lte[r.lte]; !ok || high < current {
+				lte[r.lte] = high
+			}
+		}
+
+		if !exact {
+			plan.filters = append(plan.filters, f)
+		}
+	}
+
+	for option, value := range gte {
+		plan.discoverOptions[option] = strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	for option, value := range lte {
+		plan.discoverOptions[option] = strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	return plan
+}
+
+// bounds returns the inclusive range of values a numeric filter accepts and
+// whether that range is exactly what the filter accepts. Exclusive bounds are
+// only exact for integer fields compared against whole numbers.
+func (f MovieFilter) bounds(integer bool) (low, high float64, exact bool) {
+	value := f.Value.(float64)
+	whole := integer && value == math.Trunc(value)
+
+	switch f.Operator {
+	case OpGe:
+		return value, math.Inf(1), true
+	case OpLe:
+		return math.Inf(-1), value, true
+	case OpEq:
+		return value, value, true
+	case OpGt:
+		if whole {
+			return value + 1, math.Inf(1), true
+		}
+		return value, math.Inf(1), false
+	case OpLt:
+		if whole {
+			return math.Inf(-1), value - 1, true
+		}
+		return math.Inf(-1), value, false
+	case OpBetween:
+		low, high, exact = value, f.Max, true
+		if f.MinExclusive {
+			if integer && low == math.Trunc(low) {
+				low++
+			} else {
+				exact = false
+			}
+		}
+		if f.MaxExclusive {
+			if integer && high == math.Trunc(high) {
+				high--
+			} else {
+				exact = false
+			}
+		}
+		return low, high, exact
+	default:
+		return math.Inf(-1), math.Inf(1), false
+	}
+}
+
+// checksDetails tells whether some of the query is left to check on the
+// details of the movies TMDB lists
+func (p *queryPlan) checksDetails() bool {
+	return len(p.filters) > 0 || p.expression != nil
+}
+
+// matches tells whether a movie passes what is left of the query once the
+// plan has been applied
+func (p *queryPlan) matches(movie *tmdb.MovieDetails) (bool, error) {
+	if p.unknowns != nil && (p.counted == nil || p.counted(movie)) {
+		p.unknowns.add(movie)
+	}
+
+	for _, f := range p.filters {
+		if !f.matches(movie) {
+			return false, nil
+		}
+	}
+
+	return matchesExpression(p.expression, movie)
+}
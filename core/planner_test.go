@@ -0,0 +1,144 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/affanshahid/convoluted-movie-finder/core/mocks"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPlanQuery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		filters  []MovieFilter
+		options  map[string]string
+		residual []MovieFilter
+	}{
+		{
+			"inclusive bounds",
+			[]MovieFilter{{Field: "vote_average", Operator: OpGe, Value: 7.5}, {Field: "runtime", Operator: OpLe, Value: float64(120)}},
+			map[string]string{"vote_average.gte": "7.5", "with_runtime.lte": "120"},
+			nil,
+		},
+		{
+			"exclusive integer bounds",
+			[]MovieFilter{{Field: "vote_count", Operator: OpGt, Value: float64(100)}, {Field: "runtime", Operator: OpBetween, Value: float64(90), Max: 120, MaxExclusive: true}},
+			map[string]string{"vote_count.gte": "101", "with_runtime.gte": "90", "with_runtime.lte": "119"},
+			nil,
+		},
+		{
+			"approximated bounds",
+			[]MovieFilter{{Field: "vote_average", Operator: OpGt, Value: 7.5}},
+			map[string]string{"vote_average.gte": "7.5"},
+			[]MovieFilter{{Field: "vote_average", Operator: OpGt, Value: 7.5}},
+		},
+		{
+			"narrowest bounds",
+			[]MovieFilter{{Field: "runtime", Operator: OpGe, Value: float64(80)}, {Field: "runtime", Operator: OpGe, Value: float64(90)}},
+			map[string]string{"with_runtime.gte": "90"},
+			nil,
+		},
+		{
+			"language",
+			[]MovieFilter{{Field: "original_language", Operator: OpEq, Value: "EN"}},
+			map[string]string{"with_original_language": "en"},
+			nil,
+		},
+		{
+			"unknown runtimes excluded",
+			[]MovieFilter{{Field: "runtime", Operator: OpLe, Value: float64(120), unknown: UnknownExcluded}},
+			map[string]string{"with_runtime.lte": "120"},
+			[]MovieFilter{{Field: "runtime", Operator: OpLe, Value: float64(120), unknown: UnknownExcluded}},
+		},
+		{
+			"unknown runtimes included",
+			[]MovieFilter{{Field: "runtime", Operator: OpGe, Value: float64(90), unknown: UnknownIncluded}},
+			map[string]string{},
+			[]MovieFilter{{Field: "runtime", Operator: OpGe, Value: float64(90), unknown: UnknownIncluded}},
+		},
+		{
+			"details only",
+			[]MovieFilter{{Field: "budget", Operator: OpGt, Value: float64(1)}, {Field: "runtime", Operator: OpNe, Value: float64(90)}},
+			map[string]string{},
+			[]MovieFilter{{Field: "budget", Operator: OpGt, Value: float64(1)}, {Field: "runtime", Operator: OpNe, Value: float64(90)}},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			plan := planQuery(c.filters, map[string]string{}, nil)
+			assert.Equal(t, c.options, plan.discoverOptions)
+			assert.Equal(t, c.residual, plan.filters)
+		})
+	}
+}
+
+func TestPlanQueryKeepsRevenueComparison(t *testing.T) {
+	t.Parallel()
+
+	plan := planQuery(GenrePeriodQuery{Revenue: 1000, RevenueCheckOperator: OpGt}.filters(), map[string]string{}, nil)
+	assert.Empty(t, plan.discoverOptions)
+	assert.Equal(t, []MovieFilter{{Field: "revenue", Operator: OpGt, Value: float64(1000)}}, plan.filters)
+}
+
+func TestRevenueComparisonFollowsUnknownValues(t *testing.T) {
+	t.Parallel()
+
+	query := GenrePeriodQuery{Revenue: 1000, RevenueCheckOperator: OpLt}
+	assert.True(t, query.matches(&tmdb.MovieDetails{}))
+
+	query.UnknownValues = UnknownExcluded
+	assert.False(t, query.matches(&tmdb.MovieDetails{}))
+	assert.True(t, query.matches(&tmdb.MovieDetails{Revenue: 10}))
+}
+
+func TestFetchGenrePeriodDetailsPushesDownFilters(t *testing.T) {
+	t.Parallel()
+	mockClient := new(mocks.TmdbClient)
+	mockCache := new(mocks.MovieCache)
+	var nilmap map[string]string
+
+	mockCache.On("GetMovieDetails", mock.Anything).Return(nil, nil)
+	mockCache.On("SaveMovieDetails", mock.Anything).Return(nil)
+
+	mockClient.On("GetGenreMovieList", nilmap).Return(genreList, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+	}).Return(allMoviesDiscover, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"with_genres":      "28",
+		"vote_count.gte":   "100",
+	}).Return(actionMoviesDiscover, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"with_genres":      "28",
+		"vote_count.gte":   "100",
+		"page":             "1",
+	}).Return(actionMoviesDiscover, nil)
+	mockClient.On("GetMovieDetails", 1, nilmap).Return(someMovieDetails, nil)
+
+	svc := NewMovieService(mockClient, mockCache)
+
+	result, err := svc.FetchGenrePeriodDetails(context.Background(), GenrePeriodQuery{
+		GenreId:   28,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Filters: []MovieFilter{
+			{Field: "vote_count", Operator: OpGe, Value: float64(100)},
+			{Field: "revenue", Operator: OpEq, Value: float64(1000)},
+		},
+	})
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.Equal(t, float64(25), result.Pct)
+}
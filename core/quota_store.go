@@ -0,0 +1,13 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// QuotaStore keeps counters shared between replicas. Counters expire ttl after
+// they are first incremented.
+type QuotaStore interface {
+	IncrementCounter(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+	GetCounter(ctx context.Context, key string) (int64, error)
+}
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/affanshahid/configo"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const counterPrefix = "counter_"
+
+type QuotaStoreEtcd struct {
+	client *clientv3.Client
+}
+
+func NewQuotaStoreEtcd() (*QuotaStoreEtcd, error) {
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{configo.MustGetString("etcd_url")},
+		DialTimeout: 5 * time.Second,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &QuotaStoreEtcd{client}, nil
+}
+
+// IncrementCounter atomically adds delta to the counter, retrying whenever
+// another replica updated it in between
+func (s *QuotaStoreEtcd) IncrementCounter(
+	ctx context.Context,
+	key string,
+	delta int64,
+	ttl time.Duration,
+) (int64, error) {
+	key = counterPrefix + key
+
+	for {
+		resp, err := s.client.Get(ctx, key)
+		if err != nil {
+			return 0, err
+		}
+
+		if len(resp.Kvs) == 0 {
+			lease, err := s.client.Grant(ctx, int64(ttl.Seconds()))
+			if err != nil {
+				return 0, err
+			}
+
+			txnResp, err := s.client.Txn(ctx).
+				If(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).
+				Then(clientv3.OpPut(key, strconv.FormatInt(delta, 10), clientv3.WithLease(lease.ID))).
+				Commit()
+			if err == nil && txnResp.Succeeded {
+				return delta, nil
+			}
+
+			s.revoke(ctx, lease.ID)
+			if err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		kv := resp.Kvs[0]
+		current, err := strconv.ParseInt(string(kv.Value), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+
+		next := current + delta
+		txnResp, err := s.client.Txn(ctx).
+			If(clientv3.Compare(clientv3.ModRevision(key), "=", kv.ModRevision)).
+			Then(clientv3.OpPut(key, strconv.FormatInt(next, 10), clientv3.WithIgnoreLease())).
+			Commit()
+		if err != nil {
+			return 0, err
+		}
+
+		if txnResp.Succeeded {
+			return next, nil
+		}
+	}
+}
+
+// revoke drops the lease of a counter that wasn't created, a lease that can't
+// be revoked only lives until its ttl
+func (s *QuotaStoreEtcd) revoke(ctx context.Context, id clientv3.LeaseID) {
+	if _, err := s.client.Revoke(ctx, id); err != nil {
+		log.Printf("unable to revoke quota counter lease %x: %v", id, err)
+	}
+}
+
+func (s *QuotaStoreEtcd) GetCounter(ctx context.Context, key string) (int64, error) {
+	resp, err := s.client.Get(ctx, counterPrefix+key)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(resp.Kvs) == 0 {
+		return 0, nil
+	}
+
+	return strconv.ParseInt(string(resp.Kvs[0].Value), 10, 64)
+}
+
+func (s *QuotaStoreEtcd) Close() error {
+	return s.client.Close()
+}
+
+var _ QuotaStore = (*QuotaStoreEtcd)(nil)
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncrementCounter(t *testing.T) {
+	setupEtcd(t)
+	defer cleanupEtcd()
+
+	store, err := NewQuotaStoreEtcd()
+	assert.Nilf(t, err, "expected err to be nil")
+	defer store.Close()
+
+	count, err := store.IncrementCounter(context.Background(), "some_counter", 1, time.Minute)
+	assert.Nilf(t, err, "expected err to be nil")
+	assert.Equal(t, int64(1), count)
+
+	count, err = store.IncrementCounter(context.Background(), "some_counter", 5, time.Minute)
+	assert.Nilf(t, err, "expected err to be nil")
+	assert.Equal(t, int64(6), count)
+
+	count, err = store.GetCounter(context.Background(), "some_counter")
+	assert.Nilf(t, err, "expected err to be nil")
+	assert.Equal(t, int64(6), count)
+}
+
+func TestIncrementCounterConcurrently(t *testing.T) {
+	setupEtcd(t)
+	defer cleanupEtcd()
+
+	store, err := NewQuotaStoreEtcd()
+	assert.Nilf(t, err, "expected err to be nil")
+	defer store.Close()
+
+	done := make(chan error)
+	for i := 0; i < 10; i++ {
+		go func() {
+			_, err := store.IncrementCounter(context.Background(), "some_counter", 1, time.Minute)
+			done <- err
+		}()
+	}
+
+	for i := 0; i < 10; i++ {
+		assert.Nilf(t, <-done, "expected err to be nil")
+	}
+
+	count, err := store.GetCounter(context.Background(), "some_counter")
+	assert.Nilf(t, err, "expected err to be nil")
+	assert.Equal(t, int64(10), count)
+}
+
+func TestGetCounterReturnsZeroIfNotSet(t *testing.T) {
+	setupEtcd(t)
+	defer cleanupEtcd()
+
+	store, err := NewQuotaStoreEtcd()
+	assert.Nilf(t, err, "expected err to be nil")
+	defer store.Close()
+
+	count, err := store.GetCounter(context.Background(), "some_counter")
+	assert.Nilf(t, err, "expected err to be nil")
+	assert.Equal(t, int64(0), count)
+}
@@ -0,0 +1,257 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/antonmedv/expr/vm"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"golang.org/x/sync/errgroup"
+)
+
+// maxSeriesBuckets bounds the TMDB calls of a series, each bucket costs one to
+// count the movies released in it
+const maxSeriesBuckets = 520
+
+// Granularity is the length of the buckets a series splits its period into.
+// Buckets follow the calendar, weeks start on Monday.
+type Granularity uint8
+
+const (
+	ByMonth Granularity = iota
+	ByWeek
+	ByQuarter
+	ByYear
+)
+
+func (g Granularity) IsValid() bool {
+	return g <= ByYear
+}
+
+// GenreSeriesQuery splits the period of a genre query into buckets. Sorting and
+// paging don't apply, every bucket is aggregated as with IncludeStats.
+type GenreSeriesQuery struct {
+	GenrePeriodQuery
+	Granularity Granularity
+}
+
+// GenreSeries describes the requested genres like GenrePeriodDetails, with the
+// share of the genres in every bucket of the period
+type GenreSeries struct {
+	Id             int64
+	Name           string
+	Genres         []Genre
+	ExcludedGenres []Genre
+	Match          GenreMatch
+	Buckets        []SeriesBucket
+}
+
+// SeriesBucket holds the movies released from StartDate to EndDate, both
+// inclusive. The first and last buckets are cut short by the period.
+type SeriesBucket struct {
+	StartDate   time.Time
+	EndDate     time.Time
+	GenreMovies int
+	TotalMovies int64
+	Pct         float64
+	Stats       *MovieStats
+}
+
+// FetchGenreSeries fetches the movies of the whole period once and groups them
+// by bucket, while the movies released in each bucket are counted
+// concurrently. Movies are selected and counted by their primary release date,
+// the one they are grouped by, rather than by any of their regional releases.
+func (s *MovieService) FetchGenreSeries(ctx context.Context, query GenreSeriesQuery) (GenreSeries, error) {
+	var series GenreSeries
+
+	expression, err := s.validateSeriesQuery(query)
+	if err != nil {
+		return series, err
+	}
+
+	included, excluded, err := s.resolveGenres(ctx, query.GenrePeriodQuery)
+	if err != nil {
+		return series, err
+	}
+
+	series.Genres = included
+	series.ExcludedGenres = excluded
+	series.Match = query.GenreMatch
+	if len(included) == 1 {
+		series.Id = included[0].Id
+		series.Name = included[0].Name
+	}
+
+	options := genreDiscoverOptions(query.GenrePeriodQuery, included, excluded)
+	delete(options, "release_date.gte")
+	delete(options, "release_date.lte")
+	for key, value := range primaryReleaseDateOptions(query.StartDate, query.EndDate) {
+		options[key] = value
+	}
+
+	plan := planQuery(query.filters(), options, expression)
+	series.Buckets = splitPeriod(query.StartDate, query.EndDate, query.Granularity)
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for i := range series.Buckets {
+		bucket := &series.Buckets[i]
+		eg.Go(func() error {
+			if err := egCtx.Err(); err != nil {
+				return err
+			}
+
+			result, err := s.clientFor(egCtx).GetDiscoverMovie(primaryReleaseDateOptions(bucket.StartDate, bucket.EndDate))
+			if err != nil {
+				return err
+			}
+
+			bucket.TotalMovies = result.TotalResults
+			return nil
+		})
+	}
+
+	var movies []*tmdb.MovieDetails
+	eg.Go(func() error {
+		var err error
+		movies, err = s.fetchMovies(egCtx, plan)
+		return err
+	})
+
+	if err := eg.Wait(); err != nil {
+		return series, err
+	}
+
+	grouped := groupByBucket(series.Buckets, movies)
+	for i := range series.Buckets {
+		bucket := &series.Buckets[i]
+		bucket.GenreMovies = len(grouped[i])
+		bucket.Stats = computeStats(grouped[i])
+		if bucket.TotalMovies > 0 {
+			bucket.Pct = float64(bucket.GenreMovies) / float64(bucket.TotalMovies) * 100
+		}
+	}
+
+	return series, nil
+}
+
+func (s *MovieService) validateSeriesQuery(query GenreSeriesQuery) (*vm.Program, error) {
+	expression, err := s.validateQuery(query.GenrePeriodQuery)
+
+	v, err := violationsOf(err)
+	if err != nil {
+		return nil, err
+	}
+
+	if !query.Granularity.IsValid() {
+		v.add(ErrInvalidRequest, "granularity", fmt.Sprintf("unknown granularity %d", query.Granularity))
+	} else if !query.StartDate.IsZero() && !query.EndDate.IsZero() {
+		buckets := 0
+		end := truncateToDay(query.EndDate)
+		for start := startOfBucket(truncateToDay(query.StartDate), query.Granularity); !start.After(end) && buckets <= maxSeriesBuckets; {
+			buckets++
+			start = nextBucket(start, query.Granularity)
+		}
+
+		if buckets > maxSeriesBuckets {
+			v.add(ErrInvalidRange, "granularity", fmt.Sprintf("must not split the period into more than %d buckets", maxSeriesBuckets))
+		}
+	}
+
+	return expression, v.err()
+}
+
+// primaryReleaseDateOptions selects the movies first released from start to
+// end, both inclusive
+func primaryReleaseDateOptions(start, end time.Time) map[string]string {
+	return map[string]string{
+		"primary_release_date.gte": start.Format(timeFormat),
+		"primary_release_date.lte": end.Format(timeFormat),
+	}
+}
+
+// splitPeriod returns the calendar buckets of the given granularity covering
+// the days from start to end
+func splitPeriod(start, end time.Time, granularity Granularity) []SeriesBucket {
+	start = truncateToDay(start)
+	end = truncateToDay(end)
+
+	var buckets []SeriesBucket
+	for bucketStart := startOfBucket(start, granularity); !bucketStart.After(end); {
+		next := nextBucket(bucketStart, granularity)
+
+		bucket := SeriesBucket{StartDate: bucketStart, EndDate: next.AddDate(0, 0, -1)}
+		if bucket.StartDate.Before(start) {
+			bucket.StartDate = start
+		}
+		if bucket.EndDate.After(end) {
+			bucket.EndDate = end
+		}
+
+		buckets = append(buckets, bucket)
+		bucketStart = next
+	}
+
+	return buckets
+}
+
+func truncateToDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+func startOfBucket(day time.Time, granularity Granularity) time.Time {
+	year, month, _ := day.Date()
+
+	switch granularity {
+	case ByWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case ByQuarter:
+		return time.Date(year, month-(month-1)%3, 1, 0, 0, 0, 0, day.Location())
+	case ByYear:
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, day.Location())
+	default:
+		return time.Date(year, month, 1, 0, 0, 0, 0, day.Location())
+	}
+}
+
+func nextBucket(bucketStart time.Time, granularity Granularity) time.Time {
+	switch granularity {
+	case ByWeek:
+		return bucketStart.AddDate(0, 0, 7)
+	case ByQuarter:
+		return bucketStart.AddDate(0, 3, 0)
+	case ByYear:
+		return bucketStart.AddDate(1, 0, 0)
+	default:
+		return bucketStart.AddDate(0, 1, 0)
+	}
+}
+
+// groupByBucket assigns movies to the bucket they were first released in.
+// Movies are selected by that date, so all of them land in a bucket unless TMDB
+// changed their date in between.
+func groupByBucket(buckets []SeriesBucket, movies []*tmdb.MovieDetails) [][]*tmdb.MovieDetails {
+	grouped := make([][]*tmdb.MovieDetails, len(buckets))
+	if len(buckets) == 0 {
+		return grouped
+	}
+
+	location := buckets[0].StartDate.Location()
+	for _, movie := range movies {
+		released, err := time.ParseInLocation(timeFormat, movie.ReleaseDate, location)
+		if err != nil || released.Before(buckets[0].StartDate) {
+			continue
+		}
+
+		i := sort.Search(len(buckets), func(i int) bool {
+			return !buckets[i].EndDate.Before(released)
+		})
+		if i < len(buckets) {
+			grouped[i] = append(grouped[i], movie)
+		}
+	}
+
+	return grouped
+}
@@ -0,0 +1,240 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/affanshahid/convoluted-movie-finder/core/mocks"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestSplitPeriod(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		start, end  time.Time
+		granularity Granularity
+		expected    []SeriesBucket
+	}{
+		{
+			"months cut short by the period",
+			date(2021, 1, 15), date(2021, 3, 10), ByMonth,
+			[]SeriesBucket{
+				{StartDate: date(2021, 1, 15), EndDate: date(2021, 1, 31)},
+				{StartDate: date(2021, 2, 1), EndDate: date(2021, 2, 28)},
+				{StartDate: date(2021, 3, 1), EndDate: date(2021, 3, 10)},
+			},
+		},
+		{
+			"weeks starting on monday",
+			date(2021, 11, 10), date(2021, 11, 22), ByWeek,
+			[]SeriesBucket{
+				{StartDate: date(2021, 11, 10), EndDate: date(2021, 11, 14)},
+				{StartDate: date(2021, 11, 15), EndDate: date(2021, 11, 21)},
+				{StartDate: date(2021, 11, 22), EndDate: date(2021, 11, 22)},
+			},
+		},
+		{
+			"quarters",
+			date(2021, 2, 1), date(2021, 7, 1), ByQuarter,
+			[]SeriesBucket{
+				{StartDate: date(2021, 2, 1), EndDate: date(2021, 3, 31)},
+				{StartDate: date(2021, 4, 1), EndDate: date(2021, 6, 30)},
+				{StartDate: date(2021, 7, 1), EndDate: date(2021, 7, 1)},
+			},
+		},
+		{
+			"years",
+			date(2020, 6, 1), date(2021, 12, 31), ByYear,
+			[]SeriesBucket{
+				{StartDate: date(2020, 6, 1), EndDate: date(2020, 12, 31)},
+				{StartDate: date(2021, 1, 1), EndDate: date(2021, 12, 31)},
+			},
+		},
+		{
+			"single day",
+			date(2021, 5, 5).Add(13 * time.Hour), date(2021, 5, 5), ByMonth,
+			[]SeriesBucket{
+				{StartDate: date(2021, 5, 5), EndDate: date(2021, 5, 5)},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, c.expected, splitPeriod(c.start, c.end, c.granularity))
+		})
+	}
+}
+
+func TestGroupByBucket(t *testing.T) {
+	t.Parallel()
+	buckets := splitPeriod(date(2021, 1, 10), date(2021, 2, 20), ByMonth)
+	movies := []*tmdb.MovieDetails{
+		{ID: 1, ReleaseDate: "2021-01-10"},
+		{ID: 2, ReleaseDate: "2021-02-01"},
+		{ID: 3, ReleaseDate: "2021-01-31"},
+		{ID: 4, ReleaseDate: "2021-01-09"},
+		{ID: 5, ReleaseDate: ""},
+		{ID: 6, ReleaseDate: "2021-02-21"},
+	}
+
+	grouped := groupByBucket(buckets, movies)
+
+	assert.Equal(t, [][]int64{{1, 3}, {2}}, [][]int64{movieIds(grouped[0]), movieIds(grouped[1])})
+}
+
+func TestValidateSeriesQuery(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache))
+
+	cases := []struct {
+		name     string
+		query    GenreSeriesQuery
+		expected []FieldViolation
+	}{
+		{
+			"unknown granularity",
+			GenreSeriesQuery{GenrePeriodQuery{GenreId: 28, StartDate: startDate, EndDate: endDate, RevenueCheckOperator: OpGt}, 9},
+			[]FieldViolation{{Field: "granularity", Description: "unknown granularity 9"}},
+		},
+		{
+			"too many buckets",
+			GenreSeriesQuery{GenrePeriodQuery{GenreId: 28, StartDate: date(2000, 1, 1), EndDate: endDate, RevenueCheckOperator: OpGt}, ByWeek},
+			[]FieldViolation{{Field: "granularity", Description: "must not split the period into more than 520 buckets"}},
+		},
+		{
+			"query violations are kept",
+			GenreSeriesQuery{GenrePeriodQuery{GenreId: 28, RevenueCheckOperator: OpGt}, 9},
+			[]FieldViolation{
+				{Field: "startDate", Description: "is required"},
+				{Field: "endDate", Description: "is required"},
+				{Field: "granularity", Description: "unknown granularity 9"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := svc.validateSeriesQuery(c.query)
+
+			var invalidErr *InvalidArgumentError
+			assert.True(t, errors.As(err, &invalidErr), "expected an InvalidArgumentError")
+			assert.Equal(t, c.expected, invalidErr.Violations)
+		})
+	}
+}
+
+func TestFetchGenreSeries(t *testing.T) {
+	t.Parallel()
+	mockClient := new(mocks.TmdbClient)
+	mockCache := new(mocks.MovieCache)
+
+	var nilmap map[string]string
+
+	mockCache.On("GetMovieDetails", mock.Anything).Return(nil, nil)
+	mockCache.On("SaveMovieDetails", mock.Anything).Return(nil)
+
+	mockClient.On("GetGenreMovieList", nilmap).Return(genreList, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"primary_release_date.gte": "2021-01-01",
+		"primary_release_date.lte": "2021-03-31",
+	}).Return(allMoviesDiscover, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"primary_release_date.gte": "2021-04-01",
+		"primary_release_date.lte": "2021-06-30",
+	}).Return(actionMoviesDiscover, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"primary_release_date.gte": "2021-01-01",
+		"primary_release_date.lte": "2021-06-30",
+		"with_genres":              "28",
+	}).Return(allMoviesDiscover, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"primary_release_date.gte": "2021-01-01",
+		"primary_release_date.lte": "2021-06-30",
+		"with_genres":              "28",
+		"page":                     "1",
+	}).Return(allMoviesDiscover, nil)
+	mockClient.On("GetMovieDetails", 1, nilmap).Return(&tmdb.MovieDetails{ID: 1, ReleaseDate: "2021-02-10", Revenue: 1000, Budget: 500}, nil)
+	mockClient.On("GetMovieDetails", 2, nilmap).Return(&tmdb.MovieDetails{ID: 2, ReleaseDate: "2021-05-01"}, nil)
+	mockClient.On("GetMovieDetails", 3, nilmap).Return(&tmdb.MovieDetails{ID: 3, ReleaseDate: "2021-04-02", Revenue: 5}, nil)
+	mockClient.On("GetMovieDetails", 4, nilmap).Return(&tmdb.MovieDetails{ID: 4, ReleaseDate: "2021-03-01", Revenue: 2000, Budget: 1000}, nil)
+
+	svc := NewMovieService(mockClient, mockCache)
+
+	result, err := svc.FetchGenreSeries(context.Background(), GenreSeriesQuery{
+		GenrePeriodQuery: GenrePeriodQuery{
+			GenreId:              28,
+			StartDate:            date(2021, 1, 1),
+			EndDate:              date(2021, 6, 30),
+			Revenue:              1,
+			RevenueCheckOperator: OpGt,
+		},
+		Granularity: ByQuarter,
+	})
+
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.Equal(t, GenreSeries{
+		Id:     28,
+		Name:   "Action",
+		Genres: []Genre{{28, "Action"}},
+		Buckets: []SeriesBucket{
+			{
+				StartDate:   date(2021, 1, 1),
+				EndDate:     date(2021, 3, 31),
+				GenreMovies: 2,
+				TotalMovies: 4,
+				Pct:         50,
+				Stats: &MovieStats{
+					Count:         2,
+					TotalRevenue:  3000,
+					MeanRevenue:   1500,
+					MedianRevenue: 1500,
+					P90Revenue:    1900,
+					TotalBudget:   1500,
+					ROI:           1,
+				},
+			},
+			{
+				StartDate:   date(2021, 4, 1),
+				EndDate:     date(2021, 6, 30),
+				GenreMovies: 1,
+				TotalMovies: 1,
+				Pct:         100,
+				Stats:       &MovieStats{UnknownBudget: 1},
+			},
+		},
+	}, result)
+}
+
+func TestMaxConcurrencyBoundsCallsInFlight(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache), WithMaxConcurrency(1))
+
+	first := svc.clientFor(context.Background()).(trackedClient)
+	release, err := first.acquire()
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = svc.clientFor(ctx).(trackedClient).acquire()
+	assert.Equal(t, context.Canceled, err)
+
+	release()
+	release, err = svc.clientFor(context.Background()).(trackedClient).acquire()
+	assert.Nil(t, err)
+	release()
+}
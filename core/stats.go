@@ -0,0 +1,80 @@
+package core
+
+import (
+	"math"
+	"sort"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+// MovieStats aggregates the movies of a result. TMDB reports unknown revenues
+// and budgets as zero, movies missing either are left out of every aggregate
+// and only counted in UnknownRevenue and UnknownBudget.
+type MovieStats struct {
+	// movies the aggregates cover
+	Count         int
+	TotalRevenue  int64
+	MeanRevenue   float64
+	MedianRevenue float64
+	P90Revenue    float64
+	TotalBudget   int64
+	// total profit relative to the total budget, 1.5 meaning 150%
+	ROI            float64
+	AverageRuntime float64
+	AverageRating  float64
+	// movies left out because of an unknown revenue or budget, a movie
+	// missing both is counted in both
+	UnknownRevenue int
+	UnknownBudget  int
+}
+
+// computeStats aggregates the movies in any order
+func computeStats(movies []*tmdb.MovieDetails) *MovieStats {
+	stats := new(MovieStats)
+
+	var revenues []float64
+	var runtime, rating float64
+	for _, movie := range movies {
+		if movie.Revenue == 0 {
+			stats.UnknownRevenue++
+		}
+		if movie.Budget == 0 {
+			stats.UnknownBudget++
+		}
+		if movie.Revenue == 0 || movie.Budget == 0 {
+			continue
+		}
+
+		stats.Count++
+		stats.TotalRevenue += movie.Revenue
+		stats.TotalBudget += movie.Budget
+		revenues = append(revenues, float64(movie.Revenue))
+		runtime += float64(movie.Runtime)
+		rating += float64(movie.VoteAverage)
+	}
+
+	if stats.Count == 0 {
+		return stats
+	}
+
+	sort.Float64s(revenues)
+	count := float64(stats.Count)
+	stats.MeanRevenue = float64(stats.TotalRevenue) / count
+	stats.MedianRevenue = percentile(revenues, 50)
+	stats.P90Revenue = percentile(revenues, 90)
+	stats.ROI = float64(stats.TotalRevenue-stats.TotalBudget) / float64(stats.TotalBudget)
+	stats.AverageRuntime = runtime / count
+	stats.AverageRating = rating / count
+
+	return stats
+}
+
+// percentile interpolates linearly between the closest ranks of the sorted
+// values, which must not be empty
+func percentile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
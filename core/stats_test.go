@@ -0,0 +1,72 @@
+package core
+
+import (
+	"testing"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeStats(t *testing.T) {
+	t.Parallel()
+	movies := []*tmdb.MovieDetails{
+		{ID: 1, Revenue: 400, Budget: 100, Runtime: 100, VoteAverage: 6},
+		{ID: 2, Revenue: 100, Budget: 100, Runtime: 120, VoteAverage: 8},
+		{ID: 3, Revenue: 200, Budget: 200, Runtime: 80, VoteAverage: 7},
+		{ID: 4, Revenue: 0, Budget: 50, Runtime: 90, VoteAverage: 9},
+		{ID: 5, Revenue: 900, Budget: 0, Runtime: 90, VoteAverage: 9},
+		{ID: 6, Revenue: 0, Budget: 0},
+	}
+
+	stats := computeStats(movies)
+
+	assert.Equal(t, &MovieStats{
+		Count:          3,
+		TotalRevenue:   700,
+		MeanRevenue:    700.0 / 3,
+		MedianRevenue:  200,
+		P90Revenue:     360,
+		TotalBudget:    400,
+		ROI:            0.75,
+		AverageRuntime: 100,
+		AverageRating:  7,
+		UnknownRevenue: 2,
+		UnknownBudget:  2,
+	}, stats)
+}
+
+func TestComputeStatsWithoutKnownValues(t *testing.T) {
+	t.Parallel()
+
+	stats := computeStats([]*tmdb.MovieDetails{{ID: 1, Budget: 10}})
+
+	assert.Equal(t, &MovieStats{UnknownRevenue: 1}, stats)
+}
+
+func TestPercentile(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		values   []float64
+		p        float64
+		expected float64
+	}{
+		{[]float64{5}, 90, 5},
+		{[]float64{1, 2, 3, 4}, 50, 2.5},
+		{[]float64{1, 2, 3, 4, 5}, 90, 4.6},
+		{[]float64{1, 2, 3, 4, 5}, 100, 5},
+	}
+
+	for _, c := range cases {
+		assert.InDelta(t, c.expected, percentile(c.values, c.p), 1e-9)
+	}
+}
+
+func TestQueryFingerprintIgnoresStats(t *testing.T) {
+	t.Parallel()
+	query := GenrePeriodQuery{GenreId: 28, PageSize: 10}
+	withStats := query
+	withStats.IncludeStats = true
+
+	assert.Equal(t, queryFingerprint(query), queryFingerprint(withStats))
+}
@@ -2,10 +2,79 @@
 
 package core
 
-import tmdb "github.com/cyruzin/golang-tmdb"
+import (
+	"context"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
 
 type TmdbClient interface {
 	GetGenreMovieList(urlOptions map[string]string) (*tmdb.GenreMovieList, error)
 	GetDiscoverMovie(urlOptions map[string]string) (*tmdb.DiscoverMovie, error)
 	GetMovieDetails(id int, urlOptions map[string]string) (*tmdb.MovieDetails, error)
 }
+
+// trackedClient records every call against the request's usage and classifies
+// the errors TMDB returns. Calls wait for one of the service's slots, shared by
+// every request, so that TMDB never sees more than that many calls at once.
+type trackedClient struct {
+	ctx    context.Context
+	client TmdbClient
+	usage  *Usage
+	slots  chan struct{}
+}
+
+func (c trackedClient) GetGenreMovieList(urlOptions map[string]string) (*tmdb.GenreMovieList, error) {
+	release, err := c.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	c.usage.recordCall()
+	result, err := c.client.GetGenreMovieList(urlOptions)
+	return result, classifyUpstreamError(err)
+}
+
+func (c trackedClient) GetDiscoverMovie(urlOptions map[string]string) (*tmdb.DiscoverMovie, error) {
+	release, err := c.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	c.usage.recordCall()
+	result, err := c.client.GetDiscoverMovie(urlOptions)
+	return result, classifyUpstreamError(err)
+}
+
+func (c trackedClient) GetMovieDetails(id int, urlOptions map[string]string) (*tmdb.MovieDetails, error) {
+	release, err := c.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	c.usage.recordCall()
+	result, err := c.client.GetMovieDetails(id, urlOptions)
+	return result, classifyUpstreamError(err)
+}
+
+// acquire waits for a free slot unless the request is cancelled first, a nil
+// slots channel means no limit
+func (c trackedClient) acquire() (func(), error) {
+	if c.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case c.slots <- struct{}{}:
+		return func() { <-c.slots }, nil
+	case <-c.ctx.Done():
+		return nil, c.ctx.Err()
+	}
+}
+
+func (s *MovieService) clientFor(ctx context.Context) TmdbClient {
+	return trackedClient{ctx, s.client, usageFromContext(ctx), s.slots}
+}
@@ -0,0 +1,262 @@
+package core
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/antonmedv/expr/vm"
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+const maxTopMovies = 100
+
+// RankingMetric is the value movies are ranked by, from the highest to the
+// lowest
+type RankingMetric uint8
+
+const (
+	RankByRevenue RankingMetric = iota
+	// RankByProfit ranks by revenue minus budget
+	RankByProfit
+	// RankByROI ranks by profit relative to the budget, 1.5 meaning 150%
+	RankByROI
+	RankByRating
+	RankByPopularity
+)
+
+func (m RankingMetric) IsValid() bool {
+	return m <= RankByPopularity
+}
+
+// TopMoviesQuery ranks the movies of a genre query. Sorting, paging, stats and
+// the denominator don't apply.
+type TopMoviesQuery struct {
+	GenrePeriodQuery
+	Metric RankingMetric
+	Limit  int
+}
+
+// TopMovies describes the requested genres like GenrePeriodDetails. Movies
+// holds the best Limit movies, followed by up to Limit more movies tied for the
+// last rank, those with the lowest ids. OmittedTies counts the tied movies
+// left out beyond those. Ranked counts the matching movies whose metric is
+// known, Unranked those left out because TMDB reports a zero revenue or
+// budget, or no votes.
+type TopMovies struct {
+	Id             int64
+	Name           string
+	Genres         []Genre
+	ExcludedGenres []Genre
+	Match          GenreMatch
+	Metric         RankingMetric
+	Movies         []RankedMovie
+	OmittedTies    int
+	Ranked         int
+	Unranked       int
+}
+
+// RankedMovie shares its Rank with the movies of equal Value, the next rank
+// skips as many places, e.g. 1, 2, 2, 4
+type RankedMovie struct {
+	Rank  int
+	Value float64
+	Movie *tmdb.MovieDetails
+}
+
+// FetchTopMovies streams the details of the matching movies through a heap
+// holding the best of them, so that only those are kept in memory
+func (s *MovieService) FetchTopMovies(ctx context.Context, query TopMoviesQuery) (TopMovies, error) {
+	var top TopMovies
+	top.Metric = query.Metric
+
+	expression, err := s.validateTopMoviesQuery(query)
+	if err != nil {
+		return top, err
+	}
+
+	included, excluded, err := s.resolveGenres(ctx, query.GenrePeriodQuery)
+	if err != nil {
+		return top, err
+	}
+
+	top.Genres = included
+	top.ExcludedGenres = excluded
+	top.Match = query.GenreMatch
+	if len(included) == 1 {
+		top.Id = included[0].Id
+		top.Name = included[0].Name
+	}
+
+	plan := planQuery(query.filters(), genreDiscoverOptions(query.GenrePeriodQuery, included, excluded), expression)
+
+	ranking := newTopRanking(query.Limit)
+	err = s.streamMovies(ctx, plan, func(movie *tmdb.MovieDetails) {
+		value, ok := metricOf(query.Metric, movie)
+		if !ok {
+			top.Unranked++
+			return
+		}
+
+		top.Ranked++
+		ranking.add(RankedMovie{Value: value, Movie: movie})
+	})
+	if err != nil {
+		return top, err
+	}
+
+	top.Movies = ranking.ranked()
+	top.OmittedTies = ranking.omittedTies
+
+	return top, nil
+}
+
+func (s *MovieService) validateTopMoviesQuery(query TopMoviesQuery) (*vm.Program, error) {
+	expression, err := s.validateQuery(query.GenrePeriodQuery)
+
+	v, err := violationsOf(err)
+	if err != nil {
+		return nil, err
+	}
+
+	if !query.Metric.IsValid() {
+		v.add(ErrInvalidRequest, "metric", fmt.Sprintf("unknown ranking metric %d", query.Metric))
+	}
+
+	if query.Limit < 1 || query.Limit > maxTopMovies {
+		v.add(ErrInvalidRequest, "limit", fmt.Sprintf("must be between 1 and %d", maxTopMovies))
+	}
+
+	return expression, v.err()
+}
+
+// metricOf reports false when TMDB doesn't know the value, it reports unknown
+// revenues and budgets as zero
+func metricOf(metric RankingMetric, movie *tmdb.MovieDetails) (float64, bool) {
+	switch metric {
+	case RankByProfit:
+		return float64(movie.Revenue - movie.Budget), movie.Revenue != 0 && movie.Budget != 0
+	case RankByROI:
+		if movie.Revenue == 0 || movie.Budget == 0 {
+			return 0, false
+		}
+		return float64(movie.Revenue-movie.Budget) / float64(movie.Budget), true
+	case RankByRating:
+		return widenFloat32(movie.VoteAverage), movie.VoteCount > 0
+	case RankByPopularity:
+		return widenFloat32(movie.Popularity), true
+	default:
+		return float64(movie.Revenue), movie.Revenue != 0
+	}
+}
+
+// topRanking keeps the limit best movies in a min-heap, along with up to
+// limit movies tied with the worst of them which would otherwise be dropped
+// arbitrarily. Movies of equal value are ordered by id, so the same movies are
+// kept whatever order they arrive in, and only the number of the tied movies
+// beyond those is kept, however many there are.
+type topRanking struct {
+	limit       int
+	heap        rankingHeap
+	ties        []RankedMovie
+	omittedTies int
+}
+
+func newTopRanking(limit int) *topRanking {
+	return &topRanking{limit: limit}
+}
+
+func (r *topRanking) add(movie RankedMovie) {
+	if r.heap.Len() < r.limit {
+		heap.Push(&r.heap, movie)
+		return
+	}
+
+	worst := r.heap[0]
+	switch {
+	case rankedBefore(movie, worst):
+		dropped := heap.Pop(&r.heap).(RankedMovie)
+		heap.Push(&r.heap, movie)
+
+		if r.heap[0].Value == dropped.Value {
+			r.addTie(dropped)
+		} else {
+			r.ties = nil
+			r.omittedTies = 0
+		}
+	case movie.Value == worst.Value:
+		r.addTie(movie)
+	}
+}
+
+// addTie keeps the tied movies with the lowest ids and counts the others
+func (r *topRanking) addTie(movie RankedMovie) {
+	if len(r.ties) < r.limit {
+		r.ties = append(r.ties, movie)
+		return
+	}
+
+	r.omittedTies++
+
+	last := 0
+	for i := range r.ties {
+		if r.ties[i].Movie.ID > r.ties[last].Movie.ID {
+			last = i
+		}
+	}
+
+	if movie.Movie.ID < r.ties[last].Movie.ID {
+		r.ties[last] = movie
+	}
+}
+
+// ranked orders the movies from the highest to the lowest value and by id for
+// equal values
+func (r *topRanking) ranked() []RankedMovie {
+	movies := make([]RankedMovie, 0, r.heap.Len()+len(r.ties))
+	movies = append(movies, r.heap...)
+	movies = append(movies, r.ties...)
+
+	sort.Slice(movies, func(i, j int) bool {
+		return rankedBefore(movies[i], movies[j])
+	})
+
+	for i := range movies {
+		if i > 0 && movies[i].Value == movies[i-1].Value {
+			movies[i].Rank = movies[i-1].Rank
+		} else {
+			movies[i].Rank = i + 1
+		}
+	}
+
+	return movies
+}
+
+// rankedBefore orders movies from the highest to the lowest value and by id
+// for equal values
+func rankedBefore(a, b RankedMovie) bool {
+	if a.Value != b.Value {
+		return a.Value > b.Value
+	}
+
+	return a.Movie.ID < b.Movie.ID
+}
+
+// rankingHeap implements heap.Interface with the last ranked movie on top
+type rankingHeap []RankedMovie
+
+func (h rankingHeap) Len() int           { return len(h) }
+func (h rankingHeap) Less(i, j int) bool { return rankedBefore(h[j], h[i]) }
+func (h rankingHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *rankingHeap) Push(x interface{}) {
+	*h = append(*h, x.(RankedMovie))
+}
+
+func (h *rankingHeap) Pop() interface{} {
+	old := *h
+	movie := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return movie
+}
@@ -0,0 +1,166 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/affanshahid/convoluted-movie-finder/core/mocks"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func rankedIds(movies []RankedMovie) (ids []int64, ranks []int) {
+	for _, m := range movies {
+		ids = append(ids, m.Movie.ID)
+		ranks = append(ranks, m.Rank)
+	}
+
+	return ids, ranks
+}
+
+func TestTopRanking(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name          string
+		limit         int
+		values        []float64
+		expectedIds   []int64
+		expectedRanks []int
+		omittedTies   int
+	}{
+		{"fewer movies than the limit", 5, []float64{3, 1, 2}, []int64{1, 3, 2}, []int{1, 2, 3}, 0},
+		{"best movies are kept", 2, []float64{1, 5, 3, 4, 2}, []int64{2, 4}, []int{1, 2}, 0},
+		{"ties share a rank", 4, []float64{5, 3, 3, 1, 2}, []int64{1, 2, 3, 5}, []int{1, 2, 2, 4}, 0},
+		{"ties for the last rank are kept", 2, []float64{4, 2, 2, 1, 2}, []int64{1, 2, 3, 5}, []int{1, 2, 2, 2}, 0},
+		{"ties pushed out together", 2, []float64{2, 2, 2, 3, 4}, []int64{5, 4}, []int{1, 2}, 0},
+		{"displaced movie tied with the worst", 2, []float64{2, 2, 5}, []int64{3, 1, 2}, []int{1, 2, 2}, 0},
+		{"ties beyond the limit are counted", 2, []float64{5, 1, 1, 1, 1, 1}, []int64{1, 2, 3, 4}, []int{1, 2, 2, 2}, 2},
+		{"lowest ids are kept among ties", 2, []float64{1, 1, 1, 1, 1, 5}, []int64{6, 1, 2, 3}, []int{1, 2, 2, 2}, 2},
+		{"omitted ties pushed out", 1, []float64{1, 1, 1, 2}, []int64{4}, []int{1}, 0},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			ranking := newTopRanking(c.limit)
+			for i, value := range c.values {
+				ranking.add(RankedMovie{Value: value, Movie: &tmdb.MovieDetails{ID: int64(i + 1)}})
+			}
+
+			ids, ranks := rankedIds(ranking.ranked())
+			assert.Equal(t, c.expectedIds, ids)
+			assert.Equal(t, c.expectedRanks, ranks)
+			assert.Equal(t, c.omittedTies, ranking.omittedTies)
+		})
+	}
+}
+
+func TestMetricOf(t *testing.T) {
+	t.Parallel()
+	movie := &tmdb.MovieDetails{Revenue: 3000, Budget: 1000, VoteAverage: 7.5, VoteCount: 10, Popularity: 12.5}
+	unknown := &tmdb.MovieDetails{Revenue: 3000}
+	rated := &tmdb.MovieDetails{VoteAverage: 7.3, VoteCount: 10, Popularity: 12.3}
+
+	cases := []struct {
+		metric        RankingMetric
+		movie         *tmdb.MovieDetails
+		expected      float64
+		expectedKnown bool
+	}{
+		{RankByRevenue, movie, 3000, true},
+		{RankByProfit, movie, 2000, true},
+		{RankByROI, movie, 2, true},
+		{RankByRating, movie, 7.5, true},
+		{RankByPopularity, movie, 12.5, true},
+		{RankByRevenue, &tmdb.MovieDetails{}, 0, false},
+		{RankByProfit, unknown, 3000, false},
+		{RankByROI, unknown, 0, false},
+		{RankByRating, unknown, 0, false},
+		{RankByPopularity, unknown, 0, true},
+		{RankByRating, rated, 7.3, true},
+		{RankByPopularity, rated, 12.3, true},
+	}
+
+	for _, c := range cases {
+		value, known := metricOf(c.metric, c.movie)
+		assert.Equal(t, c.expected, value)
+		assert.Equal(t, c.expectedKnown, known)
+	}
+}
+
+func TestFetchTopMovies(t *testing.T) {
+	t.Parallel()
+	mockClient := new(mocks.TmdbClient)
+	mockCache := new(mocks.MovieCache)
+
+	var nilmap map[string]string
+
+	mockCache.On("GetMovieDetails", mock.Anything).Return(nil, nil)
+	mockCache.On("SaveMovieDetails", mock.Anything).Return(nil)
+
+	mockClient.On("GetGenreMovieList", nilmap).Return(genreList, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"with_genres":      "28",
+	}).Return(allMoviesDiscover, nil)
+	mockClient.On("GetDiscoverMovie", map[string]string{
+		"release_date.gte": startDate.Format(timeFormat),
+		"release_date.lte": endDate.Format(timeFormat),
+		"with_genres":      "28",
+		"page":             "1",
+	}).Return(allMoviesDiscover, nil)
+	mockClient.On("GetMovieDetails", 1, nilmap).Return(&tmdb.MovieDetails{ID: 1, Revenue: 3000, Budget: 1000}, nil)
+	mockClient.On("GetMovieDetails", 2, nilmap).Return(&tmdb.MovieDetails{ID: 2, Revenue: 5000}, nil)
+	mockClient.On("GetMovieDetails", 3, nilmap).Return(&tmdb.MovieDetails{ID: 3, Revenue: 1500, Budget: 500}, nil)
+	mockClient.On("GetMovieDetails", 4, nilmap).Return(&tmdb.MovieDetails{ID: 4, Revenue: 100, Budget: 1000}, nil)
+
+	svc := NewMovieService(mockClient, mockCache)
+
+	result, err := svc.FetchTopMovies(context.Background(), TopMoviesQuery{
+		GenrePeriodQuery: GenrePeriodQuery{
+			GenreId:              28,
+			StartDate:            startDate,
+			EndDate:              endDate,
+			RevenueCheckOperator: OpGt,
+		},
+		Metric: RankByROI,
+		Limit:  2,
+	})
+
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.Equal(t, int64(28), result.Id)
+	assert.Equal(t, "Action", result.Name)
+	assert.Equal(t, RankByROI, result.Metric)
+	assert.Equal(t, 3, result.Ranked)
+	assert.Equal(t, 1, result.Unranked)
+
+	ids, ranks := rankedIds(result.Movies)
+	assert.Equal(t, []int64{1, 3}, ids)
+	assert.Equal(t, []int{1, 1}, ranks)
+	assert.Equal(t, float64(2), result.Movies[0].Value)
+}
+
+func TestValidateTopMoviesQuery(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache))
+
+	_, err := svc.validateTopMoviesQuery(TopMoviesQuery{
+		GenrePeriodQuery: GenrePeriodQuery{GenreId: 28, RevenueCheckOperator: OpGt},
+		Metric:           9,
+		Limit:            101,
+	})
+
+	var invalidErr *InvalidArgumentError
+	assert.True(t, errors.As(err, &invalidErr), "expected an InvalidArgumentError")
+	assert.Equal(t, []FieldViolation{
+		{Field: "startDate", Description: "is required"},
+		{Field: "endDate", Description: "is required"},
+		{Field: "metric", Description: "unknown ranking metric 9"},
+		{Field: "limit", Description: "must be between 1 and 100"},
+	}, invalidErr.Violations)
+}
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Usage records the TMDB calls made on behalf of a single request, cache hits
+// are not counted
+type Usage struct {
+	tmdbCalls int64
+}
+
+func (u *Usage) TmdbCalls() int64 {
+	return atomic.LoadInt64(&u.tmdbCalls)
+}
+
+func (u *Usage) recordCall() {
+	if u != nil {
+		atomic.AddInt64(&u.tmdbCalls, 1)
+	}
+}
+
+type usageKey struct{}
+
+// WithUsage returns a context that makes MovieService record its TMDB calls
+// into usage
+func WithUsage(ctx context.Context, usage *Usage) context.Context {
+	return context.WithValue(ctx, usageKey{}, usage)
+}
+
+func usageFromContext(ctx context.Context) *Usage {
+	usage, _ := ctx.Value(usageKey{}).(*Usage)
+	return usage
+}
@@ -0,0 +1,217 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/antonmedv/expr/vm"
+)
+
+var languagePattern = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
+
+func (op Operator) IsValid() bool {
+	return op <= OpBetween
+}
+
+func (m GenreMatch) IsValid() bool {
+	return m <= MatchAny
+}
+
+func (d Denominator) IsValid() bool {
+	return d <= DenominatorFiltered
+}
+
+// ValidateQuery checks the query without contacting TMDB and returns an
+// InvalidArgumentError listing every field that was rejected
+func (s *MovieService) ValidateQuery(query GenrePeriodQuery) error {
+	_, err := s.validateQuery(query)
+	return err
+}
+
+// validateQuery also returns the compiled filter expression so that it is only
+// compiled once per query
+func (s *MovieService) validateQuery(query GenrePeriodQuery) (*vm.Program, error) {
+	var v violations
+
+	switch {
+	case query.GenreName != "" && query.GenreId != 0:
+		v.add(ErrInvalidRequest, "genreName", "must not be set together with genreId")
+	case query.GenreId < 0 || query.GenreId == 0 && query.GenreName == "" && len(query.GenreIds) == 0 && len(query.GenreNames) == 0:
+		v.add(ErrInvalidRequest, "genreId", "must be a positive TMDB genre id")
+	}
+
+	v.addGenreIds("genreIds", query.GenreIds)
+	v.addGenreNames("genreNames", query.GenreNames)
+	v.addGenreIds("excludedGenreIds", query.ExcludedGenreIds)
+	v.addGenreNames("excludedGenreNames", query.ExcludedGenreNames)
+
+	if !query.GenreMatch.IsValid() {
+		v.add(ErrInvalidRequest, "genreMatch", fmt.Sprintf("unknown genre match %d", query.GenreMatch))
+	}
+
+	v.addLanguage(query.Language)
+
+	s.addPeriodViolations(&v, query.StartDate, query.EndDate)
+
+	if !query.SortBy.IsValid() {
+		v.add(ErrInvalidRequest, "sortBy", fmt.Sprintf("unknown sort field %d", query.SortBy))
+	}
+
+	if !query.Denominator.IsValid() {
+		v.add(ErrInvalidRequest, "denominator", fmt.Sprintf("unknown denominator %d", query.Denominator))
+	}
+
+	if !query.UnknownValues.IsValid() {
+		v.add(ErrInvalidRequest, "unknownValues", fmt.Sprintf("unknown policy %d", query.UnknownValues))
+	}
+
+	if query.PageSize < 0 || query.PageSize > maxPageSize {
+		v.add(ErrInvalidRequest, "pageSize", fmt.Sprintf("must be between 0 and %d", maxPageSize))
+	}
+
+	if _, err := decodePageToken(query); err != nil {
+		v.add(ErrInvalidRequest, "pageToken", err.Error())
+	}
+
+	expression := s.addFilterViolations(&v, query)
+	return expression, v.err()
+}
+
+func (s *MovieService) addPeriodViolations(v *violations, start, end time.Time) {
+	s.addNamedPeriodViolations(v, "startDate", "endDate", start, end)
+}
+
+// addNamedPeriodViolations reports the violations of a period under the given
+// field names
+func (s *MovieService) addNamedPeriodViolations(v *violations, startField, endField string, start, end time.Time) {
+	if start.IsZero() {
+		v.add(ErrInvalidRequest, startField, "is required")
+	}
+
+	if end.IsZero() {
+		v.add(ErrInvalidRequest, endField, "is required")
+	}
+
+	if !start.IsZero() && !end.IsZero() {
+		span := end.Sub(start)
+		if span < 0 {
+			v.add(ErrInvalidRange, startField, "must not be after "+endField)
+		} else if s.maxPeriodSpan > 0 && span > s.maxPeriodSpan {
+			v.add(ErrInvalidRange, endField, fmt.Sprintf("must be within %s of %s", formatSpan(s.maxPeriodSpan), startField))
+		}
+	}
+}
+
+// addFilterViolations checks the filters, the expression or the revenue
+// comparison of a query and returns the compiled expression
+func (s *MovieService) addFilterViolations(v *violations, query GenrePeriodQuery) *vm.Program {
+	expression, err := s.compileExpression(query.Expression, query.UnknownValues)
+	if err != nil {
+		v.add(ErrInvalidRequest, "expression", err.Error())
+	}
+
+	if len(query.Filters) > 0 || query.Expression != "" {
+		if query.Revenue != 0 || query.RevenueCheckOperator != OpLt || query.RevenueMax != 0 ||
+			query.RevenueMinExclusive || query.RevenueMaxExclusive {
+			v.add(ErrInvalidRequest, "revenue", "must not be set together with filters or an expression")
+		}
+
+		for i, f := range query.Filters {
+			f.validate(v, fmt.Sprintf("filters[%d]", i))
+		}
+
+		return expression
+	}
+
+	if query.Revenue < 0 {
+		v.add(ErrInvalidRequest, "revenue", "must not be negative")
+	}
+
+	if !query.RevenueCheckOperator.IsValid() {
+		v.add(ErrInvalidRequest, "revenueCheckOperator", fmt.Sprintf("unknown operator %d", query.RevenueCheckOperator))
+	}
+
+	if query.RevenueCheckOperator == OpBetween {
+		switch {
+		case query.RevenueMax < query.Revenue:
+			v.add(ErrInvalidRequest, "revenueMax", "must not be less than revenue")
+		case query.RevenueMax == query.Revenue && (query.RevenueMinExclusive || query.RevenueMaxExclusive):
+			v.add(ErrInvalidRequest, "revenueMax", "must be greater than revenue when a bound is exclusive")
+		}
+	} else if query.RevenueMax != 0 || query.RevenueMinExclusive || query.RevenueMaxExclusive {
+		v.add(ErrInvalidRequest, "revenueMax", "must only be set with the between operator")
+	}
+
+	return expression
+}
+
+// violations collects field violations into a single InvalidArgumentError.
+// The error is reported as ErrInvalidRange only if nothing but the range was
+// rejected.
+type violations struct {
+	kind error
+	list []FieldViolation
+}
+
+func (v *violations) add(kind error, field, description string) {
+	if v.kind == nil || kind == ErrInvalidRequest {
+		v.kind = kind
+	}
+
+	v.list = append(v.list, FieldViolation{Field: field, Description: description})
+}
+
+func (v *violations) addGenreIds(field string, ids []int64) {
+	for _, id := range ids {
+		if id <= 0 {
+			v.add(ErrInvalidRequest, field, "must only contain positive TMDB genre ids")
+			return
+		}
+	}
+}
+
+func (v *violations) addGenreNames(field string, names []string) {
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			v.add(ErrInvalidRequest, field, "must not contain empty names")
+			return
+		}
+	}
+}
+
+func (v *violations) addLanguage(language string) {
+	if language != "" && !languagePattern.MatchString(language) {
+		v.add(ErrInvalidRequest, "language", "must be an ISO 639-1 code, optionally followed by a region such as en-US")
+	}
+}
+
+// violationsOf resumes collecting the violations of an InvalidArgumentError,
+// any other error is returned as is
+func violationsOf(err error) (violations, error) {
+	var invalidErr *InvalidArgumentError
+	if errors.As(err, &invalidErr) {
+		return violations{kind: invalidErr.Kind, list: invalidErr.Violations}, nil
+	}
+
+	return violations{}, err
+}
+
+func (v *violations) err() error {
+	if len(v.list) == 0 {
+		return nil
+	}
+
+	return &InvalidArgumentError{Kind: v.kind, Violations: v.list}
+}
+
+func formatSpan(span time.Duration) string {
+	days := int64(span / (24 * time.Hour))
+	if days*int64(24*time.Hour) == int64(span) {
+		return fmt.Sprintf("%d days", days)
+	}
+
+	return span.String()
+}
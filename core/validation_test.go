@@ -0,0 +1,106 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/affanshahid/convoluted-movie-finder/core/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var validQuery = GenrePeriodQuery{
+	GenreId:              28,
+	StartDate:            startDate,
+	EndDate:              endDate,
+	Revenue:              1,
+	RevenueCheckOperator: OpGt,
+}
+
+func TestValidateQuery(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache))
+
+	assert.Nil(t, svc.ValidateQuery(validQuery))
+}
+
+func TestValidateQueryRejectsInvalidFields(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache))
+
+	cases := []struct {
+		name   string
+		modify func(q *GenrePeriodQuery)
+		kind   error
+		field  string
+	}{
+		{"missing genre", func(q *GenrePeriodQuery) { q.GenreId = 0 }, ErrInvalidRequest, "genreId"},
+		{"missing start date", func(q *GenrePeriodQuery) { q.StartDate = time.Time{} }, ErrInvalidRequest, "startDate"},
+		{"missing end date", func(q *GenrePeriodQuery) { q.EndDate = time.Time{} }, ErrInvalidRequest, "endDate"},
+		{"start after end", func(q *GenrePeriodQuery) { q.StartDate, q.EndDate = q.EndDate, q.StartDate }, ErrInvalidRange, "startDate"},
+		{"negative revenue", func(q *GenrePeriodQuery) { q.Revenue = -1 }, ErrInvalidRequest, "revenue"},
+		{"unknown operator", func(q *GenrePeriodQuery) { q.RevenueCheckOperator = 42 }, ErrInvalidRequest, "revenueCheckOperator"},
+		{"invalid genre ids", func(q *GenrePeriodQuery) { q.GenreIds = []int64{12, -1} }, ErrInvalidRequest, "genreIds"},
+		{"empty genre name", func(q *GenrePeriodQuery) { q.GenreNames = []string{" "} }, ErrInvalidRequest, "genreNames"},
+		{"invalid excluded genre ids", func(q *GenrePeriodQuery) { q.ExcludedGenreIds = []int64{0} }, ErrInvalidRequest, "excludedGenreIds"},
+		{"between without max", func(q *GenrePeriodQuery) { q.RevenueCheckOperator = OpBetween }, ErrInvalidRequest, "revenueMax"},
+		{"empty exclusive range", func(q *GenrePeriodQuery) {
+			q.RevenueCheckOperator, q.RevenueMax, q.RevenueMinExclusive = OpBetween, q.Revenue, true
+		}, ErrInvalidRequest, "revenueMax"},
+		{"max without between", func(q *GenrePeriodQuery) { q.RevenueMax = 100 }, ErrInvalidRequest, "revenueMax"},
+		{"unknown genre match", func(q *GenrePeriodQuery) { q.GenreMatch = 7 }, ErrInvalidRequest, "genreMatch"},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			query := validQuery
+			c.modify(&query)
+
+			err := svc.ValidateQuery(query)
+
+			var invalidErr *InvalidArgumentError
+			assert.ErrorAs(t, err, &invalidErr)
+			assert.ErrorIs(t, err, c.kind)
+			assert.Len(t, invalidErr.Violations, 1)
+			assert.Equal(t, c.field, invalidErr.Violations[0].Field)
+		})
+	}
+}
+
+func TestValidateQueryAcceptsGenreLists(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache))
+
+	query := validQuery
+	query.GenreId = 0
+	query.GenreIds = []int64{28, 878}
+	query.GenreMatch = MatchAny
+
+	assert.Nil(t, svc.ValidateQuery(query))
+}
+
+func TestValidateQueryRejectsLongPeriods(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache), WithMaxPeriodSpan(30*24*time.Hour))
+
+	err := svc.ValidateQuery(validQuery)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+	assert.EqualError(t, err, "invalid range: endDate must be within 30 days of startDate")
+}
+
+func TestValidateQueryReportsEveryViolation(t *testing.T) {
+	t.Parallel()
+	svc := NewMovieService(new(mocks.TmdbClient), new(mocks.MovieCache))
+
+	query := validQuery
+	query.GenreId = 0
+	query.StartDate, query.EndDate = query.EndDate, query.StartDate
+
+	err := svc.ValidateQuery(query)
+
+	var invalidErr *InvalidArgumentError
+	assert.ErrorAs(t, err, &invalidErr)
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+	assert.Len(t, invalidErr.Violations, 2)
+}
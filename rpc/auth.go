@@ -0,0 +1,153 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/affanshahid/convoluted-movie-finder/core"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeader = "authorization"
+	apiKeyHeader        = "x-api-key"
+	bearerPrefix        = "bearer "
+)
+
+// methods reachable without credentials, so orchestrators can probe the server
+const healthMethodPrefix = "/grpc.health.v1.Health/"
+
+type principalKey struct{}
+
+// PrincipalFromContext returns the principal attached by the auth interceptors
+func PrincipalFromContext(ctx context.Context) (string, bool) {
+	principal, ok := ctx.Value(principalKey{}).(string)
+	return principal, ok
+}
+
+type authenticator struct {
+	stores []core.KeyStore
+}
+
+// newAuthenticator checks keys against the static ones, as listed under
+// auth_api_keys (principal: key), first and falls back to the given store
+func newAuthenticator(apiKeys map[string]interface{}, store core.KeyStore) *authenticator {
+	static := core.StaticKeyStore{}
+	for principal, key := range apiKeys {
+		static[fmt.Sprint(key)] = principal
+	}
+
+	a := &authenticator{stores: []core.KeyStore{static}}
+	if store != nil {
+		a.stores = append(a.stores, store)
+	}
+
+	return a
+}
+
+func (a *authenticator) authenticate(ctx context.Context) (context.Context, error) {
+	key := credentialsFromContext(ctx)
+	if key == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing credentials, send a bearer token or an x-api-key header")
+	}
+
+	for _, store := range a.stores {
+		principal, err := store.LookupKey(ctx, key)
+		if err == nil {
+			return context.WithValue(ctx, principalKey{}, principal), nil
+		}
+
+		if !errors.Is(err, core.ErrUnknownKey) {
+			log.Printf("unable to look up api key: %v", err)
+			return nil, status.Error(codes.Unavailable, "unable to verify credentials")
+		}
+	}
+
+	return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+}
+
+func (a *authenticator) unaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if strings.HasPrefix(info.FullMethod, healthMethodPrefix) {
+		return handler(ctx, req)
+	}
+
+	authCtx, err := a.authenticate(ctx)
+	if err != nil {
+		logAuthFailure(ctx, info.FullMethod, err)
+		return nil, err
+	}
+
+	return handler(authCtx, req)
+}
+
+func (a *authenticator) streamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if strings.HasPrefix(info.FullMethod, healthMethodPrefix) {
+		return handler(srv, ss)
+	}
+
+	authCtx, err := a.authenticate(ss.Context())
+	if err != nil {
+		logAuthFailure(ss.Context(), info.FullMethod, err)
+		return err
+	}
+
+	return handler(srv, &contextServerStream{ss, authCtx})
+}
+
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func credentialsFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if values := md.Get(authorizationHeader); len(values) > 0 {
+		if strings.HasPrefix(strings.ToLower(values[0]), bearerPrefix) {
+			return strings.TrimSpace(values[0][len(bearerPrefix):])
+		}
+	}
+
+	if values := md.Get(apiKeyHeader); len(values) > 0 {
+		return strings.TrimSpace(values[0])
+	}
+
+	return ""
+}
+
+func peerAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "unknown"
+	}
+
+	return p.Addr.String()
+}
+
+func logAuthFailure(ctx context.Context, method string, err error) {
+	log.Printf("rejected %s from %s: %s", method, peerAddress(ctx), status.Convert(err).Message())
+}
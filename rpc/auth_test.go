@@ -0,0 +1,176 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/affanshahid/convoluted-movie-finder/core"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// memoryKeyStore is a KeyStore backed by a map, failing every lookup with err
+// when set
+type memoryKeyStore struct {
+	keys map[string]string
+	err  error
+}
+
+func (s *memoryKeyStore) LookupKey(ctx context.Context, key string) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+
+	principal, ok := s.keys[key]
+	if !ok {
+		return "", core.ErrUnknownKey
+	}
+
+	return principal, nil
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func withMetadata(pairs ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(pairs...))
+}
+
+func TestCredentialsFromContext(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		ctx      context.Context
+		expected string
+	}{
+		{"bearer token", withMetadata("authorization", "Bearer abc"), "abc"},
+		{"lowercase bearer", withMetadata("authorization", "bearer  abc "), "abc"},
+		{"api key header", withMetadata("x-api-key", " abc "), "abc"},
+		{"bearer over api key", withMetadata("authorization", "Bearer abc", "x-api-key", "def"), "abc"},
+		{"other scheme falls back to api key", withMetadata("authorization", "Basic abc", "x-api-key", "def"), "def"},
+		{"other scheme", withMetadata("authorization", "Basic abc"), ""},
+		{"no metadata", context.Background(), ""},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, c.expected, credentialsFromContext(c.ctx))
+		})
+	}
+}
+
+func TestAuthenticatorUnaryInterceptor(t *testing.T) {
+	t.Parallel()
+
+	store := &memoryKeyStore{keys: map[string]string{"stored-key": "bob"}}
+
+	cases := []struct {
+		name      string
+		store     core.KeyStore
+		ctx       context.Context
+		method    string
+		code      codes.Code
+		principal string
+	}{
+		{"bearer token", store, withMetadata("authorization", "Bearer stored-key"), "/Movie/ListGenres", codes.OK, "bob"},
+		{"api key header", store, withMetadata("x-api-key", "stored-key"), "/Movie/ListGenres", codes.OK, "bob"},
+		{"static key", store, withMetadata("x-api-key", "static-key"), "/Movie/ListGenres", codes.OK, "alice"},
+		{"static key without store", nil, withMetadata("x-api-key", "static-key"), "/Movie/ListGenres", codes.OK, "alice"},
+		{"missing credentials", store, context.Background(), "/Movie/ListGenres", codes.Unauthenticated, ""},
+		{"unknown key", store, withMetadata("x-api-key", "nope"), "/Movie/ListGenres", codes.Unauthenticated, ""},
+		{
+			"store failure",
+			&memoryKeyStore{err: errors.New("etcd down")},
+			withMetadata("x-api-key", "stored-key"),
+			"/Movie/ListGenres",
+			codes.Unavailable,
+			"",
+		},
+		{"health check", store, context.Background(), "/grpc.health.v1.Health/Check", codes.OK, ""},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			auth := newAuthenticator(map[string]interface{}{"alice": "static-key"}, c.store)
+
+			var principal string
+			called := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				principal, _ = PrincipalFromContext(ctx)
+				return "ok", nil
+			}
+
+			_, err := auth.unaryInterceptor(c.ctx, nil, &grpc.UnaryServerInfo{FullMethod: c.method}, handler)
+
+			assert.Equal(t, c.code, status.Code(err))
+			assert.Equal(t, c.code == codes.OK, called)
+			assert.Equal(t, c.principal, principal)
+		})
+	}
+}
+
+func TestAuthenticatorStreamInterceptor(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		ctx       context.Context
+		method    string
+		code      codes.Code
+		principal string
+	}{
+		{"bearer token", withMetadata("authorization", "Bearer static-key"), "/Movie/Watch", codes.OK, "alice"},
+		{"missing credentials", context.Background(), "/Movie/Watch", codes.Unauthenticated, ""},
+		{"unknown key", withMetadata("authorization", "Bearer nope"), "/Movie/Watch", codes.Unauthenticated, ""},
+		{"health watch", context.Background(), "/grpc.health.v1.Health/Watch", codes.OK, ""},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			auth := newAuthenticator(map[string]interface{}{"alice": "static-key"}, nil)
+
+			var principal string
+			called := false
+			handler := func(srv interface{}, ss grpc.ServerStream) error {
+				called = true
+				principal, _ = PrincipalFromContext(ss.Context())
+				return nil
+			}
+
+			err := auth.streamInterceptor(nil, &fakeServerStream{ctx: c.ctx}, &grpc.StreamServerInfo{FullMethod: c.method}, handler)
+
+			assert.Equal(t, c.code, status.Code(err))
+			assert.Equal(t, c.code == codes.OK, called)
+			assert.Equal(t, c.principal, principal)
+		})
+	}
+}
+
+func TestPrincipalFromContext(t *testing.T) {
+	t.Parallel()
+
+	_, ok := PrincipalFromContext(context.Background())
+	assert.False(t, ok)
+
+	principal, ok := PrincipalFromContext(context.WithValue(context.Background(), principalKey{}, "alice"))
+	assert.True(t, ok)
+	assert.Equal(t, "alice", principal)
+}
@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/affanshahid/convoluted-movie-finder/core"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const errorDomain = "movie-finder"
+
+// toStatus translates errors returned by core into gRPC statuses carrying the
+// matching error details
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var details []protoiface.MessageV1
+	code := codes.Unknown
+
+	var invalidErr *core.InvalidArgumentError
+	var dependencyErr *core.DependencyError
+	var genreErr *core.GenreNotFoundError
+
+	// a dependency timing out is reported as such, not as the client's
+	// deadline
+	switch {
+	case errors.As(err, &dependencyErr):
+		code, details = dependencyStatus(dependencyErr)
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.As(err, &invalidErr):
+		code = codes.InvalidArgument
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range invalidErr.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		}
+		details = append(details, badRequest)
+	case errors.As(err, &genreErr):
+		code = codes.NotFound
+		resourceInfo := &errdetails.ResourceInfo{ResourceType: "genre", ResourceName: genreErr.Name}
+		if len(genreErr.Suggestions) > 0 {
+			resourceInfo.Description = "did you mean " + strings.Join(genreErr.Suggestions, ", ") + "?"
+		}
+		details = append(details, resourceInfo)
+	case errors.Is(err, core.ErrGenreNotFound):
+		code = codes.NotFound
+		details = append(details, &errdetails.ResourceInfo{
+			ResourceType: "genre",
+			Description:  err.Error(),
+		})
+	}
+
+	st := status.New(code, err.Error())
+	if len(details) > 0 {
+		if withDetails, detailsErr := st.WithDetails(details...); detailsErr == nil {
+			st = withDetails
+		}
+	}
+
+	return st.Err()
+}
+
+func dependencyStatus(err *core.DependencyError) (codes.Code, []protoiface.MessageV1) {
+	var code codes.Code
+	var reason string
+
+	switch err.Kind {
+	case core.ErrUpstreamAuth:
+		code, reason = codes.FailedPrecondition, "TMDB_AUTH_FAILED"
+	case core.ErrRateLimited:
+		code, reason = codes.ResourceExhausted, "TMDB_RATE_LIMITED"
+	case core.ErrCacheUnavailable:
+		code, reason = codes.Unavailable, "CACHE_UNAVAILABLE"
+	default:
+		code, reason = codes.Unavailable, "TMDB_UNAVAILABLE"
+	}
+
+	details := []protoiface.MessageV1{&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}}
+	if err.RetryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(err.RetryAfter)})
+	}
+
+	return code, details
+}
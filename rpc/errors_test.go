@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/affanshahid/convoluted-movie-finder/core"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		err      error
+		expected codes.Code
+	}{
+		{"genre not found", core.ErrGenreNotFound, codes.NotFound},
+		{"invalid range", &core.InvalidArgumentError{Kind: core.ErrInvalidRange}, codes.InvalidArgument},
+		{"upstream auth", &core.DependencyError{Kind: core.ErrUpstreamAuth, Err: errors.New("401")}, codes.FailedPrecondition},
+		{"upstream unavailable", &core.DependencyError{Kind: core.ErrUpstreamUnavailable, Err: errors.New("timeout")}, codes.Unavailable},
+		{"rate limited", &core.DependencyError{Kind: core.ErrRateLimited, Err: errors.New("429")}, codes.ResourceExhausted},
+		{"cache unavailable", &core.DependencyError{Kind: core.ErrCacheUnavailable, Err: errors.New("down")}, codes.Unavailable},
+		{"cache deadline", &core.DependencyError{Kind: core.ErrCacheUnavailable, Err: context.DeadlineExceeded}, codes.Unavailable},
+		{"cancelled", fmt.Errorf("fetching: %w", context.Canceled), codes.Canceled},
+		{"deadline", fmt.Errorf("fetching: %w", context.DeadlineExceeded), codes.DeadlineExceeded},
+		{"unknown", errors.New("some error occurred"), codes.Unknown},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, c.expected, status.Code(toStatus(c.err)))
+		})
+	}
+}
+
+func TestToStatusAddsFieldViolations(t *testing.T) {
+	t.Parallel()
+	err := toStatus(&core.InvalidArgumentError{
+		Kind: core.ErrInvalidRange,
+		Violations: []core.FieldViolation{
+			{Field: "startDate", Description: "must not be after endDate"},
+		},
+	})
+
+	details := status.Convert(err).Details()
+	assert.Len(t, details, 1)
+
+	badRequest, ok := details[0].(*errdetails.BadRequest)
+	assert.True(t, ok, "expected BadRequest details")
+	assert.Equal(t, "startDate", badRequest.FieldViolations[0].Field)
+	assert.Equal(t, "must not be after endDate", badRequest.FieldViolations[0].Description)
+}
+
+func TestToStatusAddsRetryInfo(t *testing.T) {
+	t.Parallel()
+	err := toStatus(&core.DependencyError{
+		Kind:       core.ErrRateLimited,
+		RetryAfter: 10 * time.Second,
+		Err:        errors.New("429"),
+	})
+
+	var retryInfo *errdetails.RetryInfo
+	for _, detail := range status.Convert(err).Details() {
+		if d, ok := detail.(*errdetails.RetryInfo); ok {
+			retryInfo = d
+		}
+	}
+
+	assert.NotNil(t, retryInfo, "expected RetryInfo details")
+	assert.Equal(t, 10*time.Second, retryInfo.RetryDelay.AsDuration())
+}
+
+func TestToStatusAddsGenreSuggestions(t *testing.T) {
+	t.Parallel()
+	err := toStatus(&core.GenreNotFoundError{Name: "thril", Suggestions: []string{"Thriller"}})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+
+	var resourceInfo *errdetails.ResourceInfo
+	for _, detail := range st.Details() {
+		if d, ok := detail.(*errdetails.ResourceInfo); ok {
+			resourceInfo = d
+		}
+	}
+
+	assert.NotNil(t, resourceInfo, "expected ResourceInfo details")
+	assert.Equal(t, "genre", resourceInfo.ResourceType)
+	assert.Equal(t, "thril", resourceInfo.ResourceName)
+	assert.Contains(t, resourceInfo.Description, "Thriller")
+}
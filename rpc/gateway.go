@@ -0,0 +1,631 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/affanshahid/convoluted-movie-finder/core"
+	"github.com/affanshahid/convoluted-movie-finder/rpc/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const openAPIPath = "/v1/openapi.json"
+
+// gatewayHeaders are forwarded to the interceptors as gRPC metadata
+var gatewayHeaders = []string{authorizationHeader, apiKeyHeader}
+
+var jsonMarshaler = protojson.MarshalOptions{EmitUnpopulated: true}
+
+var (
+	operatorEnum    = pb.GenrePeriodDetailsRequest_OP_LT.Descriptor()
+	sortFieldEnum   = pb.GenrePeriodDetailsRequest_SORT_ID.Descriptor()
+	granularityEnum = pb.GenreSeriesRequest_BY_MONTH.Descriptor()
+	denominatorEnum = pb.GenrePeriodDetailsRequest_DENOMINATOR_PERIOD.Descriptor()
+	metricEnum      = pb.TopMoviesRequest_RANK_REVENUE.Descriptor()
+	unknownEnum     = pb.GenrePeriodDetailsRequest_UNKNOWN_AS_ZERO.Descriptor()
+)
+
+// route maps an HTTP endpoint onto a Movie RPC. Requests are decoded from the
+// path and query string and run through the same interceptors as gRPC calls.
+type route struct {
+	method    string
+	pattern   string
+	rpcMethod string
+	summary   string
+	params    []routeParam
+	response  protoreflect.MessageDescriptor
+	decode    func(pathParams map[string]string, query *queryParams) proto.Message
+	call      func(ctx context.Context, req proto.Message) (proto.Message, error)
+}
+
+type routeParam struct {
+	name        string
+	in          string
+	typ         string
+	format      string
+	enum        []string
+	required    bool
+	description string
+}
+
+type gateway struct {
+	routes    []route
+	intercept grpc.UnaryServerInterceptor
+	openAPI   []byte
+}
+
+func newGateway(server pb.MovieServer, intercept grpc.UnaryServerInterceptor) (*gateway, error) {
+	g := &gateway{routes: movieRoutes(server), intercept: intercept}
+
+	doc, err := buildOpenAPI(g.routes)
+	if err != nil {
+		return nil, err
+	}
+	g.openAPI = doc
+
+	return g, nil
+}
+
+func movieRoutes(server pb.MovieServer) []route {
+	return []route{
+		{
+			method:    http.MethodGet,
+			pattern:   "/v1/genres",
+			rpcMethod: "/movie.Movie/ListGenres",
+			summary:   "TMDB movie genres",
+			params: []routeParam{
+				{name: "language", in: "query", typ: "string", description: "Language of the genre names, e.g. de or pt-BR"},
+			},
+			response: (&pb.ListGenresReply{}).ProtoReflect().Descriptor(),
+			decode: func(pathParams map[string]string, query *queryParams) proto.Message {
+				return &pb.ListGenresRequest{Language: query.values.Get("language")}
+			},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return server.ListGenres(ctx, req.(*pb.ListGenresRequest))
+			},
+		},
+		{
+			method:    http.MethodGet,
+			pattern:   "/v1/genres/compare",
+			rpcMethod: "/movie.Movie/CompareGenres",
+			summary:   "Share of every genre among the movies released in a period",
+			params: []routeParam{
+				{name: "genres", in: "query", typ: "string", description: "Comma separated TMDB genre ids or names to compare, every genre when unset"},
+				{name: "start", in: "query", typ: "string", format: "date", required: true, description: "First release date of the period"},
+				{name: "end", in: "query", typ: "string", format: "date", required: true, description: "Last release date of the period"},
+				{name: "expr", in: "query", typ: "string", description: "Boolean expression on movie details, e.g. revenue > 2 * budget. Variables: " + strings.Join(core.ExpressionVariables(), ", ")},
+				{name: "filter", in: "query", typ: "string", description: "Filter on movie details written as field:operator:value or field:between:min:max, may be repeated. Fields: " + strings.Join(core.FilterFields(), ", ")},
+				{name: "language", in: "query", typ: "string", description: "Language of the genre names, e.g. de or pt-BR"},
+			},
+			response: (&pb.CompareGenresReply{}).ProtoReflect().Descriptor(),
+			decode: func(pathParams map[string]string, query *queryParams) proto.Message {
+				req := &pb.CompareGenresRequest{
+					StartDate:  query.date("start"),
+					EndDate:    query.date("end"),
+					Filters:    query.filters("filter"),
+					Expression: query.values.Get("expr"),
+					Language:   query.values.Get("language"),
+				}
+
+				if genres := query.values.Get("genres"); genres != "" {
+					req.GenreIds, req.GenreNames = splitGenres(strings.Split(genres, ","))
+				}
+
+				return req
+			},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return server.CompareGenres(ctx, req.(*pb.CompareGenresRequest))
+			},
+		},
+		{
+			method:    http.MethodGet,
+			pattern:   "/v1/genres/cooccurrence",
+			rpcMethod: "/movie.Movie/GenreCooccurrence",
+			summary:   "How often every pair of genres is combined among the movies released in a period",
+			params: []routeParam{
+				{name: "start", in: "query", typ: "string", format: "date", required: true, description: "First release date of the period"},
+				{name: "end", in: "query", typ: "string", format: "date", required: true, description: "Last release date of the period"},
+				{name: "weightByRevenue", in: "query", typ: "boolean", description: "Also sum the revenue of the movies, fetching the details of those missing from the cache"},
+				{name: "language", in: "query", typ: "string", description: "Language of the genre names, e.g. de or pt-BR"},
+			},
+			response: (&pb.GenreCooccurrenceReply{}).ProtoReflect().Descriptor(),
+			decode: func(pathParams map[string]string, query *queryParams) proto.Message {
+				return &pb.GenreCooccurrenceRequest{
+					StartDate:       query.date("start"),
+					EndDate:         query.date("end"),
+					WeightByRevenue: query.bool("weightByRevenue"),
+					Language:        query.values.Get("language"),
+				}
+			},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return server.GenreCooccurrence(ctx, req.(*pb.GenreCooccurrenceRequest))
+			},
+		},
+		{
+			method:    http.MethodGet,
+			pattern:   "/v1/genres/{genre}/period",
+			rpcMethod: "/movie.Movie/FetchGenrePeriodDetails",
+			summary:   "Share of one or more genres among the movies released in a period",
+			params: append(queryRouteParams(),
+				routeParam{name: "sort", in: "query", typ: "string", enum: enumNames(sortFieldEnum, "SORT_"), description: "Field the movies are sorted by, defaults to id"},
+				routeParam{name: "desc", in: "query", typ: "boolean", description: "Sort in descending order"},
+				routeParam{name: "pageSize", in: "query", typ: "integer", format: "int32", description: "Number of movies per page, all of them when unset"},
+				routeParam{name: "pageToken", in: "query", typ: "string", description: "nextPageToken of the previous page"},
+				routeParam{name: "fields", in: "query", typ: "string", description: "Comma separated movie fields to return, e.g. title,revenue,genres, all of them when unset"},
+				routeParam{name: "stats", in: "query", typ: "boolean", description: "Aggregate revenue, budget, runtime and rating over every matching movie"},
+				routeParam{name: "denominator", in: "query", typ: "string", enum: enumNames(denominatorEnum, "DENOMINATOR_"), description: "Movies pct is relative to: every movie of the period, the movies of the genres or the movies of the period passing the filters, defaults to period"},
+				routeParam{name: "anomalies", in: "query", typ: "boolean", description: "Flag the movies whose revenue looks wrong"},
+			),
+			response: (&pb.GenrePeriodDetailsReply{}).ProtoReflect().Descriptor(),
+			decode: func(pathParams map[string]string, query *queryParams) proto.Message {
+				req := decodeGenrePeriodQuery(pathParams, query)
+				req.SortBy = pb.GenrePeriodDetailsRequest_SortField(query.enum("sort", sortFieldEnum, "SORT_"))
+				req.Descending = query.bool("desc")
+				req.PageSize = int32(query.int64("pageSize"))
+				req.PageToken = query.values.Get("pageToken")
+				req.MovieMask = query.fieldMask("fields")
+				req.IncludeStats = query.bool("stats")
+				req.Denominator = pb.GenrePeriodDetailsRequest_Denominator(query.enum("denominator", denominatorEnum, "DENOMINATOR_"))
+				req.DetectAnomalies = query.bool("anomalies")
+
+				return req
+			},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return server.FetchGenrePeriodDetails(ctx, req.(*pb.GenrePeriodDetailsRequest))
+			},
+		},
+		{
+			method:    http.MethodGet,
+			pattern:   "/v1/genres/{genre}/series",
+			rpcMethod: "/movie.Movie/FetchGenreSeries",
+			summary:   "Share of one or more genres in every week, month, quarter or year of a period",
+			params: append(queryRouteParams(),
+				routeParam{name: "granularity", in: "query", typ: "string", enum: enumNames(granularityEnum, "BY_"), description: "Length of the buckets, defaults to month"},
+			),
+			response: (&pb.GenreSeriesReply{}).ProtoReflect().Descriptor(),
+			decode: func(pathParams map[string]string, query *queryParams) proto.Message {
+				return &pb.GenreSeriesRequest{
+					Query:       decodeGenrePeriodQuery(pathParams, query),
+					Granularity: pb.GenreSeriesRequest_Granularity(query.enum("granularity", granularityEnum, "BY_")),
+				}
+			},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return server.FetchGenreSeries(ctx, req.(*pb.GenreSeriesRequest))
+			},
+		},
+		{
+			method:    http.MethodGet,
+			pattern:   "/v1/genres/{genre}/top",
+			rpcMethod: "/movie.Movie/TopMovies",
+			summary:   "Best movies of one or more genres released in a period",
+			params: append(queryRouteParams(),
+				routeParam{name: "metric", in: "query", typ: "string", enum: enumNames(metricEnum, "RANK_"), description: "Value the movies are ranked by, defaults to revenue"},
+				routeParam{name: "limit", in: "query", typ: "integer", format: "int32", required: true, description: "Number of movies to return, from 1 to 100"},
+				routeParam{name: "fields", in: "query", typ: "string", description: "Comma separated movie fields to return, e.g. title,revenue,genres, all of them when unset"},
+			),
+			response: (&pb.TopMoviesReply{}).ProtoReflect().Descriptor(),
+			decode: func(pathParams map[string]string, query *queryParams) proto.Message {
+				req := &pb.TopMoviesRequest{
+					Query:  decodeGenrePeriodQuery(pathParams, query),
+					Metric: pb.TopMoviesRequest_Metric(query.enum("metric", metricEnum, "RANK_")),
+					Limit:  int32(query.int64("limit")),
+				}
+				req.Query.MovieMask = query.fieldMask("fields")
+
+				return req
+			},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return server.TopMovies(ctx, req.(*pb.TopMoviesRequest))
+			},
+		},
+		{
+			method:    http.MethodGet,
+			pattern:   "/v1/genres/{genre}/period/compare",
+			rpcMethod: "/movie.Movie/ComparePeriods",
+			summary:   "Changes in the share and revenue of one or more genres from a baseline period",
+			params: append(queryRouteParams(),
+				routeParam{name: "baselineStart", in: "query", typ: "string", format: "date", required: true, description: "First release date of the baseline period"},
+				routeParam{name: "baselineEnd", in: "query", typ: "string", format: "date", required: true, description: "Last release date of the baseline period"},
+				routeParam{name: "sort", in: "query", typ: "string", enum: enumNames(sortFieldEnum, "SORT_"), description: "Field the movies are sorted by, defaults to id"},
+				routeParam{name: "desc", in: "query", typ: "boolean", description: "Sort in descending order"},
+				routeParam{name: "fields", in: "query", typ: "string", description: "Comma separated movie fields to return, e.g. title,revenue,genres, all of them when unset"},
+				routeParam{name: "denominator", in: "query", typ: "string", enum: enumNames(denominatorEnum, "DENOMINATOR_"), description: "Movies pct is relative to: every movie of the period, the movies of the genres or the movies of the period passing the filters, defaults to period"},
+			),
+			response: (&pb.ComparePeriodsReply{}).ProtoReflect().Descriptor(),
+			decode: func(pathParams map[string]string, query *queryParams) proto.Message {
+				req := &pb.ComparePeriodsRequest{
+					Query:             decodeGenrePeriodQuery(pathParams, query),
+					BaselineStartDate: query.date("baselineStart"),
+					BaselineEndDate:   query.date("baselineEnd"),
+				}
+				req.Query.SortBy = pb.GenrePeriodDetailsRequest_SortField(query.enum("sort", sortFieldEnum, "SORT_"))
+				req.Query.Descending = query.bool("desc")
+				req.Query.MovieMask = query.fieldMask("fields")
+				req.Query.Denominator = pb.GenrePeriodDetailsRequest_Denominator(query.enum("denominator", denominatorEnum, "DENOMINATOR_"))
+
+				return req
+			},
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return server.ComparePeriods(ctx, req.(*pb.ComparePeriodsRequest))
+			},
+		},
+	}
+}
+
+// queryRouteParams describes the parameters decodeGenrePeriodQuery reads
+func queryRouteParams() []routeParam {
+	return []routeParam{
+		{name: "genre", in: "path", typ: "string", required: true, description: "TMDB genre ids or names, separated by , to require all of them or by | to require any of them"},
+		{name: "exclude", in: "query", typ: "string", description: "Comma separated TMDB genre ids or names whose movies are left out"},
+		{name: "start", in: "query", typ: "string", format: "date", required: true, description: "First release date of the period"},
+		{name: "end", in: "query", typ: "string", format: "date", required: true, description: "Last release date of the period"},
+		{name: "revenue", in: "query", typ: "integer", format: "int64", description: "Revenue threshold"},
+		{name: "op", in: "query", typ: "string", enum: enumNames(operatorEnum, "OP_"), description: "Operator used to compare revenue against the threshold, defaults to lt"},
+		{name: "revenueMax", in: "query", typ: "integer", format: "int64", description: "Upper revenue bound of the between operator"},
+		{name: "minExclusive", in: "query", typ: "boolean", description: "Exclude the lower bound of the between operator"},
+		{name: "maxExclusive", in: "query", typ: "boolean", description: "Exclude the upper bound of the between operator"},
+		{name: "expr", in: "query", typ: "string", description: "Boolean expression on movie details, e.g. revenue > 2 * budget. Variables: " + strings.Join(core.ExpressionVariables(), ", ")},
+		{name: "filter", in: "query", typ: "string", description: "Filter on movie details written as field:operator:value or field:between:min:max, may be repeated. Fields: " + strings.Join(core.FilterFields(), ", ")},
+		{name: "language", in: "query", typ: "string", description: "Language of the genre name, e.g. de or pt-BR"},
+		{name: "unknown", in: "query", typ: "string", enum: enumNames(unknownEnum, "UNKNOWN_"), description: "How filters treat unknown (zero) revenues, budgets and runtimes, defaults to as_zero"},
+	}
+}
+
+// decodeGenrePeriodQuery reads the genres, period and filters shared by the
+// genre routes
+func decodeGenrePeriodQuery(pathParams map[string]string, query *queryParams) *pb.GenrePeriodDetailsRequest {
+	req := &pb.GenrePeriodDetailsRequest{
+		StartDate:            query.date("start"),
+		EndDate:              query.date("end"),
+		Revenue:              query.int64("revenue"),
+		RevenueMax:           query.int64("revenueMax"),
+		RevenueMinExclusive:  query.bool("minExclusive"),
+		RevenueMaxExclusive:  query.bool("maxExclusive"),
+		Filters:              query.filters("filter"),
+		Expression:           query.values.Get("expr"),
+		RevenueCheckOperator: pb.GenrePeriodDetailsRequest_Operator(query.enum("op", operatorEnum, "OP_")),
+		Language:             query.values.Get("language"),
+		UnknownValues:        pb.GenrePeriodDetailsRequest_UnknownValues(query.enum("unknown", unknownEnum, "UNKNOWN_")),
+	}
+
+	genres, match := query.genres("genre", pathParams["genre"])
+	req.GenreMatch = match
+	if len(genres) == 1 {
+		if id, err := strconv.ParseInt(genres[0], 10, 64); err == nil {
+			req.GenreId = id
+		} else {
+			req.GenreName = genres[0]
+		}
+	} else {
+		req.GenreIds, req.GenreNames = splitGenres(genres)
+	}
+
+	if exclude := query.values.Get("exclude"); exclude != "" {
+		req.ExcludedGenreIds, req.ExcludedGenreNames = splitGenres(strings.Split(exclude, ","))
+	}
+
+	return req
+}
+
+func (g *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == openAPIPath {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(g.openAPI)
+		return
+	}
+
+	for _, rt := range g.routes {
+		pathParams, ok := matchPath(rt.pattern, r.URL.Path)
+		if !ok {
+			continue
+		}
+
+		if r.Method != rt.method {
+			writeError(w, status.Errorf(codes.Unimplemented, "method %s not allowed on %s", r.Method, r.URL.Path), http.StatusMethodNotAllowed)
+			return
+		}
+
+		g.serveRoute(w, r, rt, pathParams)
+		return
+	}
+
+	writeError(w, status.Errorf(codes.NotFound, "no route for %s", r.URL.Path), http.StatusNotFound)
+}
+
+func (g *gateway) serveRoute(w http.ResponseWriter, r *http.Request, rt route, pathParams map[string]string) {
+	query := &queryParams{values: r.URL.Query()}
+	req := rt.decode(pathParams, query)
+	if err := query.err(); err != nil {
+		writeError(w, toStatus(err), 0)
+		return
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: rt.rpcMethod}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return rt.call(ctx, req.(proto.Message))
+	}
+
+	resp, err := g.intercept(incomingContext(r), req, info, handler)
+	if err != nil {
+		writeError(w, err, 0)
+		return
+	}
+
+	body, err := jsonMarshaler.Marshal(resp.(proto.Message))
+	if err != nil {
+		writeError(w, status.Error(codes.Internal, err.Error()), 0)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// incomingContext makes the HTTP request look like an incoming gRPC call to
+// the interceptors
+func incomingContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	for _, header := range gatewayHeaders {
+		if value := r.Header.Get(header); value != "" {
+			md.Set(header, value)
+		}
+	}
+
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+	}
+
+	return ctx
+}
+
+// writeError responds with the JSON encoding of the google.rpc.Status behind
+// err, the same body grpc-gateway produces
+func writeError(w http.ResponseWriter, err error, httpStatus int) {
+	st := status.Convert(err)
+	if httpStatus == 0 {
+		httpStatus = httpStatusFromCode(st.Code())
+	}
+
+	body, marshalErr := jsonMarshaler.Marshal(st.Proto())
+	if marshalErr != nil {
+		log.Printf("unable to encode error: %v", marshalErr)
+		body = []byte(fmt.Sprintf(`{"code":%d,"message":%q,"details":[]}`, st.Code(), st.Message()))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	w.Write(body)
+}
+
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func matchPath(pattern, path string) (map[string]string, bool) {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternSegments) != len(pathSegments) {
+		return nil, false
+	}
+
+	params := map[string]string{}
+	for i, segment := range patternSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			value, err := url.PathUnescape(pathSegments[i])
+			if err != nil {
+				return nil, false
+			}
+			params[segment[1:len(segment)-1]] = value
+		} else if segment != pathSegments[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+// queryParams decodes request parameters, collecting a field violation for
+// every parameter that can't be parsed
+type queryParams struct {
+	values     url.Values
+	violations []core.FieldViolation
+}
+
+func (q *queryParams) violate(name, description string) {
+	q.violations = append(q.violations, core.FieldViolation{Field: name, Description: description})
+}
+
+func (q *queryParams) err() error {
+	if len(q.violations) == 0 {
+		return nil
+	}
+
+	return &core.InvalidArgumentError{Kind: core.ErrInvalidRequest, Violations: q.violations}
+}
+
+func (q *queryParams) int64(name string) int64 {
+	value := q.values.Get(name)
+	if value == "" {
+		return 0
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		q.violate(name, "must be an integer")
+	}
+
+	return n
+}
+
+func (q *queryParams) bool(name string) bool {
+	value := q.values.Get(name)
+	if value == "" {
+		return false
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		q.violate(name, "must be true or false")
+	}
+
+	return b
+}
+
+// date accepts either a plain date or an RFC 3339 timestamp
+func (q *queryParams) date(name string) *timestamppb.Timestamp {
+	value := q.values.Get(name)
+	if value == "" {
+		return nil
+	}
+
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return timestamppb.New(t)
+		}
+	}
+
+	q.violate(name, "must be a date formatted as YYYY-MM-DD or an RFC 3339 timestamp")
+	return nil
+}
+
+// enum decodes an enum value written the way enumNames lists them
+func (q *queryParams) enum(name string, enum protoreflect.EnumDescriptor, prefix string) int32 {
+	value := strings.ToLower(q.values.Get(name))
+	if value == "" {
+		return 0
+	}
+
+	values := enum.Values()
+	for i := 0; i < values.Len(); i++ {
+		if enumValueName(values.Get(i), prefix) == value {
+			return int32(values.Get(i).Number())
+		}
+	}
+
+	q.violate(name, fmt.Sprintf("must be one of %s", strings.Join(enumNames(enum, prefix), ", ")))
+	return 0
+}
+
+func (q *queryParams) filters(name string) []*pb.MovieFilter {
+	var filters []*pb.MovieFilter
+	for _, value := range q.values[name] {
+		filter, err := core.ParseMovieFilter(value)
+		if err != nil {
+			q.violate(name, err.Error())
+			continue
+		}
+
+		filters = append(filters, toMovieFilterMsg(filter))
+	}
+
+	return filters
+}
+
+func (q *queryParams) fieldMask(name string) *fieldmaskpb.FieldMask {
+	value := q.values.Get(name)
+	if value == "" {
+		return nil
+	}
+
+	return &fieldmaskpb.FieldMask{Paths: strings.Split(value, ",")}
+}
+
+// genres splits a list of genres separated either by commas, requiring all of
+// them, or by pipes, requiring any of them
+func (q *queryParams) genres(name, value string) ([]string, pb.GenrePeriodDetailsRequest_GenreMatch) {
+	separator, match := ",", pb.GenrePeriodDetailsRequest_MATCH_ALL
+	if strings.Contains(value, "|") {
+		if strings.Contains(value, ",") {
+			q.violate(name, "must not mix , and | separators")
+		}
+		separator, match = "|", pb.GenrePeriodDetailsRequest_MATCH_ANY
+	}
+
+	return strings.Split(value, separator), match
+}
+
+// splitGenres tells genre ids from genre names
+func splitGenres(genres []string) (ids []int64, names []string) {
+	for _, genre := range genres {
+		if id, err := strconv.ParseInt(genre, 10, 64); err == nil {
+			ids = append(ids, id)
+		} else {
+			names = append(names, genre)
+		}
+	}
+
+	return ids, names
+}
+
+// enumValueName turns OP_GT into gt given the OP_ prefix
+func enumValueName(value protoreflect.EnumValueDescriptor, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(string(value.Name()), prefix))
+}
+
+func enumNames(enum protoreflect.EnumDescriptor, prefix string) []string {
+	values := enum.Values()
+	names := make([]string, 0, values.Len())
+	for i := 0; i < values.Len(); i++ {
+		names = append(names, enumValueName(values.Get(i), prefix))
+	}
+
+	return names
+}
+
+// chainUnaryInterceptors combines interceptors the same way
+// grpc.ChainUnaryInterceptor does, the first one being the outermost
+func chainUnaryInterceptors(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+
+		return chained(ctx, req)
+	}
+}
@@ -0,0 +1,404 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/affanshahid/convoluted-movie-finder/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeMovieServer struct {
+	pb.UnimplementedMovieServer
+	lastRequest        *pb.GenrePeriodDetailsRequest
+	lastSeriesRequest  *pb.GenreSeriesRequest
+	lastCompareRequest *pb.CompareGenresRequest
+	lastTopRequest     *pb.TopMoviesRequest
+	lastPeriodsRequest *pb.ComparePeriodsRequest
+	lastMatrixRequest  *pb.GenreCooccurrenceRequest
+}
+
+func (s *fakeMovieServer) FetchGenrePeriodDetails(
+	ctx context.Context,
+	in *pb.GenrePeriodDetailsRequest,
+) (*pb.GenrePeriodDetailsReply, error) {
+	s.lastRequest = in
+	if in.GenreId == 404 {
+		return nil, status.Error(codes.NotFound, "genre not found")
+	}
+
+	return &pb.GenrePeriodDetailsReply{GenreId: in.GenreId, Name: "Action", Pct: 25}, nil
+}
+
+func (s *fakeMovieServer) FetchGenreSeries(ctx context.Context, in *pb.GenreSeriesRequest) (*pb.GenreSeriesReply, error) {
+	s.lastSeriesRequest = in
+	return &pb.GenreSeriesReply{GenreId: in.Query.GenreId, Name: "Action"}, nil
+}
+
+func (s *fakeMovieServer) CompareGenres(ctx context.Context, in *pb.CompareGenresRequest) (*pb.CompareGenresReply, error) {
+	s.lastCompareRequest = in
+	return &pb.CompareGenresReply{TotalMovies: 4}, nil
+}
+
+func (s *fakeMovieServer) TopMovies(ctx context.Context, in *pb.TopMoviesRequest) (*pb.TopMoviesReply, error) {
+	s.lastTopRequest = in
+	return &pb.TopMoviesReply{GenreId: in.Query.GenreId, Name: "Action"}, nil
+}
+
+func (s *fakeMovieServer) ComparePeriods(ctx context.Context, in *pb.ComparePeriodsRequest) (*pb.ComparePeriodsReply, error) {
+	s.lastPeriodsRequest = in
+	return &pb.ComparePeriodsReply{}, nil
+}
+
+func (s *fakeMovieServer) GenreCooccurrence(ctx context.Context, in *pb.GenreCooccurrenceRequest) (*pb.GenreCooccurrenceReply, error) {
+	s.lastMatrixRequest = in
+	return &pb.GenreCooccurrenceReply{Movies: 4}, nil
+}
+
+func (s *fakeMovieServer) ListGenres(ctx context.Context, in *pb.ListGenresRequest) (*pb.ListGenresReply, error) {
+	return &pb.ListGenresReply{Genres: []*pb.GenreMsg{{Id: 28, Name: "Action"}}}, nil
+}
+
+func passthrough(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(ctx, req)
+}
+
+func newTestGateway(t *testing.T, intercept grpc.UnaryServerInterceptor) (*gateway, *fakeMovieServer) {
+	server := new(fakeMovieServer)
+	gw, err := newGateway(server, intercept)
+	assert.Nilf(t, err, "expected err to be nil")
+	return gw, server
+}
+
+func TestGatewayFetchGenrePeriodDetails(t *testing.T) {
+	t.Parallel()
+	gw, server := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres/28/period?start=2021-01-01&end=2021-12-31&revenue=1000&op=gt", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"genreId":"28","name":"Action","pct":25,"movies":[],"genres":[],"excludedGenres":[],"genreMatch":"MATCH_ALL","nextPageToken":"","totalMovies":"0","stats":null,"denominator":"DENOMINATOR_PERIOD","denominatorMovies":"0","anomalies":[],"anomalousMovies":"0","unknownValues":null}`, rec.Body.String())
+	assert.Equal(t, int64(28), server.lastRequest.GenreId)
+	assert.Equal(t, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), server.lastRequest.StartDate.AsTime())
+	assert.Equal(t, int64(1000), server.lastRequest.Revenue)
+	assert.Equal(t, pb.GenrePeriodDetailsRequest_OP_GT, server.lastRequest.RevenueCheckOperator)
+}
+
+func TestGatewayResolvesGenreNames(t *testing.T) {
+	t.Parallel()
+	gw, server := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres/science%20fiction/period?start=2021-01-01&end=2021-12-31", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, int64(0), server.lastRequest.GenreId)
+	assert.Equal(t, "science fiction", server.lastRequest.GenreName)
+}
+
+func TestGatewayCombinesGenres(t *testing.T) {
+	t.Parallel()
+	gw, server := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres/35%7Cromance/period?start=2021-01-01&end=2021-12-31&exclude=27,animation", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []int64{35}, server.lastRequest.GenreIds)
+	assert.Equal(t, []string{"romance"}, server.lastRequest.GenreNames)
+	assert.Equal(t, pb.GenrePeriodDetailsRequest_MATCH_ANY, server.lastRequest.GenreMatch)
+	assert.Equal(t, []int64{27}, server.lastRequest.ExcludedGenreIds)
+	assert.Equal(t, []string{"animation"}, server.lastRequest.ExcludedGenreNames)
+}
+
+func TestGatewayDecodesRevenueRanges(t *testing.T) {
+	t.Parallel()
+	gw, server := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres/28/period?start=2021-01-01&end=2021-12-31&op=between&revenue=10&revenueMax=100&maxExclusive=true", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, pb.GenrePeriodDetailsRequest_OP_BETWEEN, server.lastRequest.RevenueCheckOperator)
+	assert.Equal(t, int64(10), server.lastRequest.Revenue)
+	assert.Equal(t, int64(100), server.lastRequest.RevenueMax)
+	assert.False(t, server.lastRequest.RevenueMinExclusive)
+	assert.True(t, server.lastRequest.RevenueMaxExclusive)
+}
+
+func TestGatewayDecodesFilters(t *testing.T) {
+	t.Parallel()
+	gw, server := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres/28/period?start=2021-01-01&end=2021-12-31&filter=budget:ge:1000000&filter=original_language:eq:en", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, server.lastRequest.Filters, 2)
+	assert.Equal(t, "budget", server.lastRequest.Filters[0].Field)
+	assert.Equal(t, pb.GenrePeriodDetailsRequest_OP_GE, server.lastRequest.Filters[0].Operator)
+	assert.Equal(t, float64(1000000), server.lastRequest.Filters[0].GetNumber())
+	assert.Equal(t, "en", server.lastRequest.Filters[1].GetText())
+}
+
+func TestGatewayDecodesExpressions(t *testing.T) {
+	t.Parallel()
+	gw, server := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres/28/period?start=2021-01-01&end=2021-12-31&expr=revenue+%3E+2+*+budget", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "revenue > 2 * budget", server.lastRequest.Expression)
+}
+
+func TestGatewayDecodesPagination(t *testing.T) {
+	t.Parallel()
+	gw, server := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres/28/period?start=2021-01-01&end=2021-12-31&sort=release_date&desc=true&pageSize=20&pageToken=abc", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, pb.GenrePeriodDetailsRequest_SORT_RELEASE_DATE, server.lastRequest.SortBy)
+	assert.True(t, server.lastRequest.Descending)
+	assert.Equal(t, int32(20), server.lastRequest.PageSize)
+	assert.Equal(t, "abc", server.lastRequest.PageToken)
+}
+
+func TestGatewayDecodesFieldMasks(t *testing.T) {
+	t.Parallel()
+	gw, server := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres/28/period?start=2021-01-01&end=2021-12-31&fields=title,revenue,genres", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"title", "revenue", "genres"}, server.lastRequest.MovieMask.GetPaths())
+}
+
+func TestGatewayDecodesStats(t *testing.T) {
+	t.Parallel()
+	gw, server := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres/28/period?start=2021-01-01&end=2021-12-31&stats=true", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, server.lastRequest.IncludeStats)
+}
+
+func TestGatewayDecodesDenominators(t *testing.T) {
+	t.Parallel()
+	gw, server := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres/28/period?start=2021-01-01&end=2021-12-31&denominator=filtered", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, pb.GenrePeriodDetailsRequest_DENOMINATOR_FILTERED, server.lastRequest.Denominator)
+}
+
+func TestGatewayDecodesAnomalies(t *testing.T) {
+	t.Parallel()
+	gw, server := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres/28/period?start=2021-01-01&end=2021-12-31&anomalies=true", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, server.lastRequest.DetectAnomalies)
+}
+
+func TestGatewayDecodesUnknownValues(t *testing.T) {
+	t.Parallel()
+	gw, server := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres/28/period?start=2021-01-01&end=2021-12-31&unknown=excluded", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, pb.GenrePeriodDetailsRequest_UNKNOWN_EXCLUDED, server.lastRequest.UnknownValues)
+}
+
+func TestGatewayFetchGenreSeries(t *testing.T) {
+	t.Parallel()
+	gw, server := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres/horror/series?start=2017-01-01&end=2021-12-31&granularity=quarter&filter=budget:gt:0", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, pb.GenreSeriesRequest_BY_QUARTER, server.lastSeriesRequest.Granularity)
+	assert.Equal(t, "horror", server.lastSeriesRequest.Query.GenreName)
+	assert.Equal(t, time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), server.lastSeriesRequest.Query.StartDate.AsTime())
+	assert.Len(t, server.lastSeriesRequest.Query.Filters, 1)
+}
+
+func TestGatewayCompareGenres(t *testing.T) {
+	t.Parallel()
+	gw, server := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres/compare?start=2021-01-01&end=2021-12-31&genres=28,comedy&expr=budget+%3E+0", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"totalMovies":"4","matchingMovies":"0","genres":[]}`, rec.Body.String())
+	assert.Equal(t, []int64{28}, server.lastCompareRequest.GenreIds)
+	assert.Equal(t, []string{"comedy"}, server.lastCompareRequest.GenreNames)
+	assert.Equal(t, "budget > 0", server.lastCompareRequest.Expression)
+}
+
+func TestGatewayTopMovies(t *testing.T) {
+	t.Parallel()
+	gw, server := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres/53/top?start=2019-01-01&end=2019-12-31&metric=roi&limit=10&fields=title", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, pb.TopMoviesRequest_RANK_ROI, server.lastTopRequest.Metric)
+	assert.Equal(t, int32(10), server.lastTopRequest.Limit)
+	assert.Equal(t, int64(53), server.lastTopRequest.Query.GenreId)
+	assert.Equal(t, []string{"title"}, server.lastTopRequest.Query.MovieMask.Paths)
+}
+
+func TestGatewayComparePeriods(t *testing.T) {
+	t.Parallel()
+	gw, server := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres/thriller/period/compare?start=2021-01-01&end=2021-03-31&baselineStart=2020-01-01&baselineEnd=2020-03-31&sort=revenue", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "thriller", server.lastPeriodsRequest.Query.GenreName)
+	assert.Equal(t, pb.GenrePeriodDetailsRequest_SORT_REVENUE, server.lastPeriodsRequest.Query.SortBy)
+	assert.Equal(t, time.Date(2021, 3, 31, 0, 0, 0, 0, time.UTC), server.lastPeriodsRequest.Query.EndDate.AsTime())
+	assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), server.lastPeriodsRequest.BaselineStartDate.AsTime())
+	assert.Equal(t, time.Date(2020, 3, 31, 0, 0, 0, 0, time.UTC), server.lastPeriodsRequest.BaselineEndDate.AsTime())
+}
+
+func TestGatewayGenreCooccurrence(t *testing.T) {
+	t.Parallel()
+	gw, server := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres/cooccurrence?start=2021-01-01&end=2021-12-31&weightByRevenue=true&language=de", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"genres":[],"rows":[],"movies":"4","totalRevenue":"0","unknownRevenue":"0"}`, rec.Body.String())
+	assert.True(t, server.lastMatrixRequest.WeightByRevenue)
+	assert.Equal(t, "de", server.lastMatrixRequest.Language)
+	assert.Equal(t, time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC), server.lastMatrixRequest.EndDate.AsTime())
+}
+
+func TestGatewayListGenres(t *testing.T) {
+	t.Parallel()
+	gw, _ := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres?language=de", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"genres":[{"id":"28","name":"Action"}]}`, rec.Body.String())
+}
+
+func TestGatewayRejectsMalformedParameters(t *testing.T) {
+	t.Parallel()
+	gw, _ := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres/28/period?start=yesterday&revenue=lots&op=bigger", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var body struct {
+		Code    int
+		Details []struct {
+			FieldViolations []struct{ Field string } `json:"fieldViolations"`
+		}
+	}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, int(codes.InvalidArgument), body.Code)
+	assert.Len(t, body.Details, 1)
+	assert.Len(t, body.Details[0].FieldViolations, 3)
+}
+
+func TestGatewayTranslatesStatusCodes(t *testing.T) {
+	t.Parallel()
+	gw, _ := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/genres/404/period?start=2021-01-01&end=2021-12-31", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"code":5,"message":"genre not found","details":[]}`, rec.Body.String())
+}
+
+func TestGatewayForwardsCredentials(t *testing.T) {
+	t.Parallel()
+	var authorization []string
+	gw, _ := newTestGateway(t, func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		authorization = md.Get(authorizationHeader)
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/genres/28/period?start=2021-01-01&end=2021-12-31", nil)
+	req.Header.Set("Authorization", "Bearer some-key")
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, []string{"Bearer some-key"}, authorization)
+}
+
+func TestGatewayServesOpenAPI(t *testing.T) {
+	t.Parallel()
+	gw, _ := newTestGateway(t, passthrough)
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, openAPIPath, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var doc struct {
+		Paths      map[string]map[string]interface{}
+		Components struct {
+			Schemas map[string]interface{}
+		}
+	}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Contains(t, doc.Paths["/v1/genres/{genre}/period"], "get")
+	assert.Contains(t, doc.Components.Schemas, "movie.GenrePeriodDetailsReply")
+	assert.Contains(t, doc.Components.Schemas, "movie.MovieMsg")
+}
+
+func TestChainUnaryInterceptors(t *testing.T) {
+	t.Parallel()
+	var calls []string
+	record := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls = append(calls, name)
+			return handler(ctx, req)
+		}
+	}
+
+	chain := chainUnaryInterceptors([]grpc.UnaryServerInterceptor{record("first"), record("second")})
+	_, err := chain(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls = append(calls, "handler")
+		return nil, nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"first", "second", "handler"}, calls)
+}
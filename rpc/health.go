@@ -0,0 +1,63 @@
+package rpc
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/affanshahid/configo"
+	"github.com/affanshahid/convoluted-movie-finder/core"
+	"github.com/affanshahid/convoluted-movie-finder/rpc/pb"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Liveness is reported on the empty service name and stays SERVING for as long
+// as the process is able to answer. Readiness is reported on the Movie service
+// name and follows the outcome of the dependency checks.
+const livenessService = ""
+
+var readinessService = pb.Movie_ServiceDesc.ServiceName
+
+func newHealthServer() *health.Server {
+	hs := health.NewServer()
+	hs.SetServingStatus(livenessService, healthpb.HealthCheckResponse_SERVING)
+	hs.SetServingStatus(readinessService, healthpb.HealthCheckResponse_NOT_SERVING)
+	return hs
+}
+
+func watchReadiness(ctx context.Context, hs *health.Server, movieService *core.MovieService) {
+	interval := configo.MustGetDuration("health_check_interval")
+	timeout := configo.MustGetDuration("health_check_timeout")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	current := healthpb.HealthCheckResponse_NOT_SERVING
+	for {
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := movieService.CheckDependencies(checkCtx)
+		cancel()
+
+		next := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			next = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+
+		if next != current {
+			if err != nil {
+				log.Printf("readiness check failed: %v", err)
+			} else {
+				log.Printf("readiness check passed")
+			}
+			hs.SetServingStatus(readinessService, next)
+			current = next
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package rpc
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+func loggingUnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if strings.HasPrefix(info.FullMethod, healthMethodPrefix) {
+		return handler(ctx, req)
+	}
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		principal = "-"
+	}
+
+	log.Printf("%s %s %s %s %s", peerAddress(ctx), principal, info.FullMethod, status.Code(err), time.Since(start))
+
+	return resp, err
+}
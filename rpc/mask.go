@@ -0,0 +1,74 @@
+package rpc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/affanshahid/convoluted-movie-finder/core"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// appendMaskViolations rejects mask paths which don't name a field of msg
+func appendMaskViolations(violations []core.FieldViolation, field string, mask *fieldmaskpb.FieldMask, msg proto.Message) []core.FieldViolation {
+	for _, path := range mask.GetPaths() {
+		if _, err := fieldmaskpb.New(msg, path); err != nil {
+			violations = append(violations, core.FieldViolation{
+				Field:       field,
+				Description: fmt.Sprintf("unknown field %q", path),
+			})
+		}
+	}
+
+	return violations
+}
+
+// applyMask clears the fields of msg the mask doesn't list, an empty mask
+// keeps every field. The mask must have been validated against msg.
+func applyMask(msg proto.Message, mask *fieldmaskpb.FieldMask) {
+	if len(mask.GetPaths()) == 0 {
+		return
+	}
+
+	pruneMessage(msg.ProtoReflect(), maskTree(mask.GetPaths()))
+}
+
+// maskTree groups paths by their first field, a nil subtree keeps the whole
+// field
+func maskTree(paths []string) map[string][]string {
+	tree := map[string][]string{}
+	for _, path := range paths {
+		name, rest := path, ""
+		if i := strings.IndexByte(path, '.'); i >= 0 {
+			name, rest = path[:i], path[i+1:]
+		}
+
+		subpaths, seen := tree[name]
+		switch {
+		case seen && subpaths == nil:
+			// already kept whole
+		case rest == "":
+			tree[name] = nil
+		default:
+			tree[name] = append(subpaths, rest)
+		}
+	}
+
+	return tree
+}
+
+func pruneMessage(msg protoreflect.Message, tree map[string][]string) {
+	msg.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		subpaths, ok := tree[string(fd.Name())]
+		switch {
+		case !ok:
+			msg.Clear(fd)
+		case subpaths != nil:
+			// valid paths only traverse singular message fields
+			pruneMessage(value.Message(), maskTree(subpaths))
+		}
+
+		return true
+	})
+}
@@ -0,0 +1,98 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/affanshahid/convoluted-movie-finder/core"
+	"github.com/affanshahid/convoluted-movie-finder/rpc/pb"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestApplyMask(t *testing.T) {
+	t.Parallel()
+
+	full := &pb.MovieMsg{
+		Id:       1,
+		Title:    "Dune",
+		Revenue:  400,
+		Overview: "Sand",
+		Genres:   []*pb.GenreMsg{{Id: 878, Name: "Science Fiction"}},
+	}
+
+	tests := []struct {
+		name     string
+		paths    []string
+		expected *pb.MovieMsg
+	}{
+		{"empty mask keeps every field", nil, full},
+		{"keeps listed fields", []string{"title", "revenue"}, &pb.MovieMsg{Title: "Dune", Revenue: 400}},
+		{"keeps lists whole", []string{"id", "genres"}, &pb.MovieMsg{Id: 1, Genres: full.Genres}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			msg := proto.Clone(full).(*pb.MovieMsg)
+			applyMask(msg, &fieldmaskpb.FieldMask{Paths: tt.paths})
+
+			assert.True(t, proto.Equal(tt.expected, msg), "got %v", msg)
+		})
+	}
+}
+
+func TestApplyMaskNestedPaths(t *testing.T) {
+	t.Parallel()
+	msg := &pb.GenrePeriodDetailsRequest{
+		GenreId:   28,
+		Language:  "en",
+		StartDate: &timestamppb.Timestamp{Seconds: 10, Nanos: 5},
+	}
+
+	applyMask(msg, &fieldmaskpb.FieldMask{Paths: []string{"genreId", "startDate.seconds"}})
+
+	assert.True(t, proto.Equal(&pb.GenrePeriodDetailsRequest{
+		GenreId:   28,
+		StartDate: &timestamppb.Timestamp{Seconds: 10},
+	}, msg), "got %v", msg)
+}
+
+func TestAppendMaskViolations(t *testing.T) {
+	t.Parallel()
+	mask := &fieldmaskpb.FieldMask{Paths: []string{"title", "gross", "genres.name"}}
+
+	violations := appendMaskViolations(nil, "movieMask", mask, &pb.MovieMsg{})
+
+	assert.Equal(t, []core.FieldViolation{
+		{Field: "movieMask", Description: `unknown field "gross"`},
+		{Field: "movieMask", Description: `unknown field "genres.name"`},
+	}, violations)
+}
+
+func TestToMovieMsg(t *testing.T) {
+	t.Parallel()
+	movie := &tmdb.MovieDetails{
+		ID:      438631,
+		Title:   "Dune",
+		Runtime: 155,
+		IMDbID:  "tt1160419",
+	}
+	movie.Genres = append(movie.Genres, struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}{878, "Science Fiction"})
+
+	msg := toMovieMsg(movie)
+
+	assert.True(t, proto.Equal(&pb.MovieMsg{
+		Id:      438631,
+		Title:   "Dune",
+		Runtime: 155,
+		ImdbId:  "tt1160419",
+		Genres:  []*pb.GenreMsg{{Id: 878, Name: "Science Fiction"}},
+	}, msg), "got %v", msg)
+}
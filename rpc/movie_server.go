@@ -5,6 +5,9 @@ import (
 
 	"github.com/affanshahid/convoluted-movie-finder/core"
 	"github.com/affanshahid/convoluted-movie-finder/rpc/pb"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type movieServer struct {
@@ -16,32 +19,399 @@ func (s *movieServer) FetchGenrePeriodDetails(
 	ctx context.Context,
 	in *pb.GenrePeriodDetailsRequest,
 ) (*pb.GenrePeriodDetailsReply, error) {
-	resp, err := s.service.FetchGenrePeriodDetailsWithRevenueFilter(
-		in.GenreId,
-		in.StartDate.AsTime(),
-		in.EndDate.AsTime(),
-		in.Revenue,
-		core.Operator(in.RevenueCheckOperator),
-	)
+	if err := validateGenrePeriodDetailsRequest(in); err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp, err := s.service.FetchGenrePeriodDetails(ctx, toGenrePeriodQuery(in))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toGenrePeriodDetailsReply(resp, in.MovieMask), nil
+}
+
+func (s *movieServer) ComparePeriods(ctx context.Context, in *pb.ComparePeriodsRequest) (*pb.ComparePeriodsReply, error) {
+	if err := validateComparePeriodsRequest(in); err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp, err := s.service.ComparePeriods(ctx, core.PeriodComparisonQuery{
+		GenrePeriodQuery:  toGenrePeriodQuery(in.Query),
+		BaselineStartDate: in.BaselineStartDate.AsTime(),
+		BaselineEndDate:   in.BaselineEndDate.AsTime(),
+	})
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 
+	return &pb.ComparePeriodsReply{
+		Current:  toGenrePeriodDetailsReply(resp.Current, in.Query.MovieMask),
+		Baseline: toGenrePeriodDetailsReply(resp.Baseline, in.Query.MovieMask),
+		Deltas: &pb.PeriodDeltas{
+			Movies:        toDeltaMsg(resp.Deltas.Movies),
+			Pct:           toDeltaMsg(resp.Deltas.Pct),
+			TotalRevenue:  toDeltaMsg(resp.Deltas.TotalRevenue),
+			MeanRevenue:   toDeltaMsg(resp.Deltas.MeanRevenue),
+			MedianRevenue: toDeltaMsg(resp.Deltas.MedianRevenue),
+			P90Revenue:    toDeltaMsg(resp.Deltas.P90Revenue),
+			Roi:           toDeltaMsg(resp.Deltas.ROI),
+		},
+		OnlyInCurrent:  resp.OnlyInCurrent,
+		OnlyInBaseline: resp.OnlyInBaseline,
+	}, nil
+}
+
+func toGenrePeriodDetailsReply(resp core.GenrePeriodDetails, movieMask *fieldmaskpb.FieldMask) *pb.GenrePeriodDetailsReply {
 	reply := pb.GenrePeriodDetailsReply{
-		GenreId: resp.Id,
-		Name:    resp.Name,
-		Pct:     float32(resp.Pct),
-		Movies:  []*pb.MovieMsg{},
+		GenreId:           resp.Id,
+		Name:              resp.Name,
+		Pct:               float32(resp.Pct),
+		Movies:            []*pb.MovieMsg{},
+		Genres:            toGenreMsgs(resp.Genres),
+		ExcludedGenres:    toGenreMsgs(resp.ExcludedGenres),
+		GenreMatch:        pb.GenrePeriodDetailsRequest_GenreMatch(resp.Match),
+		NextPageToken:     resp.NextPageToken,
+		TotalMovies:       int64(resp.TotalMovies),
+		Stats:             toMovieStatsMsg(resp.Stats),
+		Denominator:       pb.GenrePeriodDetailsRequest_Denominator(resp.Denominator),
+		DenominatorMovies: resp.DenominatorMovies,
+		Anomalies:         []*pb.MovieAnomalies{},
+		AnomalousMovies:   int64(resp.AnomalousMovies),
+		UnknownValues: &pb.UnknownValueCounts{
+			Revenue: resp.UnknownValues.Revenue,
+			Budget:  resp.UnknownValues.Budget,
+			Runtime: resp.UnknownValues.Runtime,
+		},
 	}
 
 	for _, movie := range resp.Movies {
-		reply.Movies = append(reply.Movies, &pb.MovieMsg{
-			Id:          movie.ID,
-			Title:       movie.Title,
-			ReleaseDate: movie.ReleaseDate,
-			Revenue:     movie.Revenue,
+		msg := toMovieMsg(movie)
+		applyMask(msg, movieMask)
+		reply.Movies = append(reply.Movies, msg)
+
+		if anomalies, ok := resp.Anomalies[movie.ID]; ok {
+			flagged := &pb.MovieAnomalies{MovieId: movie.ID}
+			for _, a := range anomalies {
+				flagged.Anomalies = append(flagged.Anomalies, &pb.AnomalyMsg{Kind: pb.AnomalyMsg_Kind(a.Kind), Reason: a.Reason})
+			}
+			reply.Anomalies = append(reply.Anomalies, flagged)
+		}
+	}
+
+	return &reply
+}
+
+func toDeltaMsg(delta core.Delta) *pb.Delta {
+	return &pb.Delta{Absolute: delta.Absolute, Relative: delta.Relative}
+}
+
+func (s *movieServer) FetchGenreSeries(ctx context.Context, in *pb.GenreSeriesRequest) (*pb.GenreSeriesReply, error) {
+	if err := validateGenreSeriesRequest(in); err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp, err := s.service.FetchGenreSeries(ctx, core.GenreSeriesQuery{
+		GenrePeriodQuery: toGenrePeriodQuery(in.Query),
+		Granularity:      core.Granularity(in.Granularity),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	reply := pb.GenreSeriesReply{
+		GenreId:        resp.Id,
+		Name:           resp.Name,
+		Genres:         toGenreMsgs(resp.Genres),
+		ExcludedGenres: toGenreMsgs(resp.ExcludedGenres),
+		GenreMatch:     pb.GenrePeriodDetailsRequest_GenreMatch(resp.Match),
+		Buckets:        []*pb.SeriesBucket{},
+	}
+
+	for _, bucket := range resp.Buckets {
+		reply.Buckets = append(reply.Buckets, &pb.SeriesBucket{
+			StartDate:   timestamppb.New(bucket.StartDate),
+			EndDate:     timestamppb.New(bucket.EndDate),
+			GenreMovies: int64(bucket.GenreMovies),
+			TotalMovies: bucket.TotalMovies,
+			Pct:         float32(bucket.Pct),
+			Stats:       toMovieStatsMsg(bucket.Stats),
 		})
 	}
 
 	return &reply, nil
 }
+
+func (s *movieServer) TopMovies(ctx context.Context, in *pb.TopMoviesRequest) (*pb.TopMoviesReply, error) {
+	if err := validateTopMoviesRequest(in); err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp, err := s.service.FetchTopMovies(ctx, core.TopMoviesQuery{
+		GenrePeriodQuery: toGenrePeriodQuery(in.Query),
+		Metric:           core.RankingMetric(in.Metric),
+		Limit:            int(in.Limit),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	reply := pb.TopMoviesReply{
+		GenreId:        resp.Id,
+		Name:           resp.Name,
+		Genres:         toGenreMsgs(resp.Genres),
+		ExcludedGenres: toGenreMsgs(resp.ExcludedGenres),
+		GenreMatch:     pb.GenrePeriodDetailsRequest_GenreMatch(resp.Match),
+		Metric:         pb.TopMoviesRequest_Metric(resp.Metric),
+		Movies:         []*pb.RankedMovieMsg{},
+		RankedMovies:   int64(resp.Ranked),
+		UnrankedMovies: int64(resp.Unranked),
+		OmittedTies:    int64(resp.OmittedTies),
+	}
+
+	for _, ranked := range resp.Movies {
+		msg := toMovieMsg(ranked.Movie)
+		applyMask(msg, in.Query.MovieMask)
+		reply.Movies = append(reply.Movies, &pb.RankedMovieMsg{
+			Rank:  int32(ranked.Rank),
+			Value: ranked.Value,
+			Movie: msg,
+		})
+	}
+
+	return &reply, nil
+}
+
+func (s *movieServer) CompareGenres(ctx context.Context, in *pb.CompareGenresRequest) (*pb.CompareGenresReply, error) {
+	if err := validateCompareGenresRequest(in); err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp, err := s.service.CompareGenres(ctx, core.GenreComparisonQuery{
+		GenreIds:   in.GenreIds,
+		GenreNames: in.GenreNames,
+		Language:   in.Language,
+		StartDate:  in.StartDate.AsTime(),
+		EndDate:    in.EndDate.AsTime(),
+		Filters:    fromMovieFilterMsgs(in.Filters),
+		Expression: in.Expression,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	reply := pb.CompareGenresReply{
+		TotalMovies:    resp.TotalMovies,
+		MatchingMovies: int64(resp.MatchingMovies),
+		Genres:         []*pb.GenreShareMsg{},
+	}
+
+	for _, share := range resp.Genres {
+		reply.Genres = append(reply.Genres, &pb.GenreShareMsg{
+			Id:             share.Id,
+			Name:           share.Name,
+			Movies:         int64(share.Movies),
+			Pct:            float32(share.Pct),
+			TotalRevenue:   share.TotalRevenue,
+			UnknownRevenue: int64(share.UnknownRevenue),
+		})
+	}
+
+	return &reply, nil
+}
+
+func (s *movieServer) GenreCooccurrence(ctx context.Context, in *pb.GenreCooccurrenceRequest) (*pb.GenreCooccurrenceReply, error) {
+	if err := validateGenreCooccurrenceRequest(in); err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp, err := s.service.FetchGenreCooccurrence(ctx, core.GenreCooccurrenceQuery{
+		Language:        in.Language,
+		StartDate:       in.StartDate.AsTime(),
+		EndDate:         in.EndDate.AsTime(),
+		WeightByRevenue: in.WeightByRevenue,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	reply := pb.GenreCooccurrenceReply{
+		Genres:         toGenreMsgs(resp.Genres),
+		Rows:           []*pb.CooccurrenceRow{},
+		Movies:         resp.Movies,
+		TotalRevenue:   resp.TotalRevenue,
+		UnknownRevenue: resp.UnknownRevenue,
+	}
+
+	for _, cells := range resp.Matrix {
+		row := &pb.CooccurrenceRow{}
+		for _, cell := range cells {
+			row.Cells = append(row.Cells, &pb.CooccurrenceCell{
+				Movies:      cell.Movies,
+				Lift:        cell.Lift,
+				Revenue:     cell.Revenue,
+				RevenueLift: cell.RevenueLift,
+			})
+		}
+		reply.Rows = append(reply.Rows, row)
+	}
+
+	return &reply, nil
+}
+
+func (s *movieServer) ListGenres(ctx context.Context, in *pb.ListGenresRequest) (*pb.ListGenresReply, error) {
+	genres, err := s.service.ListGenres(ctx, in.Language)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.ListGenresReply{Genres: toGenreMsgs(genres)}, nil
+}
+
+// toGenrePeriodQuery expects a validated request
+func toGenrePeriodQuery(in *pb.GenrePeriodDetailsRequest) core.GenrePeriodQuery {
+	return core.GenrePeriodQuery{
+		GenreId:              in.GenreId,
+		GenreName:            in.GenreName,
+		GenreIds:             in.GenreIds,
+		GenreNames:           in.GenreNames,
+		GenreMatch:           core.GenreMatch(in.GenreMatch),
+		ExcludedGenreIds:     in.ExcludedGenreIds,
+		ExcludedGenreNames:   in.ExcludedGenreNames,
+		Language:             in.Language,
+		StartDate:            in.StartDate.AsTime(),
+		EndDate:              in.EndDate.AsTime(),
+		Revenue:              in.Revenue,
+		RevenueMax:           in.RevenueMax,
+		RevenueMinExclusive:  in.RevenueMinExclusive,
+		RevenueMaxExclusive:  in.RevenueMaxExclusive,
+		RevenueCheckOperator: core.Operator(in.RevenueCheckOperator),
+		Filters:              fromMovieFilterMsgs(in.Filters),
+		Expression:           in.Expression,
+		SortBy:               core.SortField(in.SortBy),
+		SortDescending:       in.Descending,
+		PageSize:             int(in.PageSize),
+		PageToken:            in.PageToken,
+		IncludeStats:         in.IncludeStats,
+		Denominator:          core.Denominator(in.Denominator),
+		DetectAnomalies:      in.DetectAnomalies,
+		UnknownValues:        core.UnknownValuePolicy(in.UnknownValues),
+	}
+}
+
+func toMovieMsg(movie *tmdb.MovieDetails) *pb.MovieMsg {
+	msg := &pb.MovieMsg{
+		Id:           movie.ID,
+		Title:        movie.Title,
+		ReleaseDate:  movie.ReleaseDate,
+		Revenue:      movie.Revenue,
+		Budget:       movie.Budget,
+		Runtime:      int32(movie.Runtime),
+		VoteAverage:  movie.VoteAverage,
+		VoteCount:    movie.VoteCount,
+		Popularity:   movie.Popularity,
+		PosterPath:   movie.PosterPath,
+		BackdropPath: movie.BackdropPath,
+		Overview:     movie.Overview,
+		ImdbId:       movie.IMDbID,
+	}
+
+	for _, g := range movie.Genres {
+		msg.Genres = append(msg.Genres, &pb.GenreMsg{Id: g.ID, Name: g.Name})
+	}
+
+	for _, c := range movie.ProductionCompanies {
+		msg.ProductionCompanies = append(msg.ProductionCompanies, &pb.CompanyMsg{
+			Id:            c.ID,
+			Name:          c.Name,
+			LogoPath:      c.LogoPath,
+			OriginCountry: c.OriginCountry,
+		})
+	}
+
+	for _, c := range movie.ProductionCountries {
+		msg.ProductionCountries = append(msg.ProductionCountries, &pb.CountryMsg{Code: c.Iso3166_1, Name: c.Name})
+	}
+
+	for _, l := range movie.SpokenLanguages {
+		msg.SpokenLanguages = append(msg.SpokenLanguages, &pb.LanguageMsg{Code: l.Iso639_1, Name: l.Name})
+	}
+
+	return msg
+}
+
+func toMovieStatsMsg(stats *core.MovieStats) *pb.MovieStats {
+	if stats == nil {
+		return nil
+	}
+
+	return &pb.MovieStats{
+		Count:          int64(stats.Count),
+		TotalRevenue:   stats.TotalRevenue,
+		MeanRevenue:    stats.MeanRevenue,
+		MedianRevenue:  stats.MedianRevenue,
+		P90Revenue:     stats.P90Revenue,
+		TotalBudget:    stats.TotalBudget,
+		Roi:            stats.ROI,
+		AverageRuntime: stats.AverageRuntime,
+		AverageRating:  stats.AverageRating,
+		UnknownRevenue: int64(stats.UnknownRevenue),
+		UnknownBudget:  int64(stats.UnknownBudget),
+	}
+}
+
+func toGenreMsgs(genres []core.Genre) []*pb.GenreMsg {
+	msgs := []*pb.GenreMsg{}
+	for _, g := range genres {
+		msgs = append(msgs, &pb.GenreMsg{Id: g.Id, Name: g.Name})
+	}
+
+	return msgs
+}
+
+func fromMovieFilterMsgs(msgs []*pb.MovieFilter) []core.MovieFilter {
+	var filters []core.MovieFilter
+	for _, msg := range msgs {
+		filter := core.MovieFilter{
+			Field:        msg.Field,
+			Operator:     core.Operator(msg.Operator),
+			Max:          msg.Max,
+			MinExclusive: msg.MinExclusive,
+			MaxExclusive: msg.MaxExclusive,
+		}
+
+		switch value := msg.Value.(type) {
+		case *pb.MovieFilter_Number:
+			filter.Value = value.Number
+		case *pb.MovieFilter_Text:
+			filter.Value = value.Text
+		case *pb.MovieFilter_Flag:
+			filter.Value = value.Flag
+		}
+
+		filters = append(filters, filter)
+	}
+
+	return filters
+}
+
+func toMovieFilterMsg(filter core.MovieFilter) *pb.MovieFilter {
+	msg := &pb.MovieFilter{
+		Field:        filter.Field,
+		Operator:     pb.GenrePeriodDetailsRequest_Operator(filter.Operator),
+		Max:          filter.Max,
+		MinExclusive: filter.MinExclusive,
+		MaxExclusive: filter.MaxExclusive,
+	}
+
+	switch value := filter.Value.(type) {
+	case float64:
+		msg.Value = &pb.MovieFilter_Number{Number: value}
+	case string:
+		msg.Value = &pb.MovieFilter_Text{Text: value}
+	case bool:
+		msg.Value = &pb.MovieFilter_Flag{Flag: value}
+	}
+
+	return msg
+}
@@ -0,0 +1,170 @@
+package rpc
+
+import (
+	"encoding/json"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// buildOpenAPI generates an OpenAPI 3 document for the gateway routes, the
+// response schemas are derived from the protobuf descriptors so they follow
+// the protojson encoding of the replies
+func buildOpenAPI(routes []route) ([]byte, error) {
+	schemas := map[string]interface{}{
+		"google.rpc.Status": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"code":    map[string]interface{}{"type": "integer", "format": "int32"},
+				"message": map[string]interface{}{"type": "string"},
+				"details": map[string]interface{}{
+					"type":  "array",
+					"items": map[string]interface{}{"type": "object"},
+				},
+			},
+		},
+	}
+
+	paths := map[string]interface{}{}
+	for _, rt := range routes {
+		addMessageSchema(schemas, rt.response)
+
+		params := make([]interface{}, 0, len(rt.params))
+		for _, p := range rt.params {
+			schema := map[string]interface{}{"type": p.typ}
+			if p.format != "" {
+				schema["format"] = p.format
+			}
+			if len(p.enum) > 0 {
+				schema["enum"] = p.enum
+			}
+
+			params = append(params, map[string]interface{}{
+				"name":        p.name,
+				"in":          p.in,
+				"required":    p.required,
+				"description": p.description,
+				"schema":      schema,
+			})
+		}
+
+		operationId := rt.rpcMethod[strings.LastIndex(rt.rpcMethod, "/")+1:]
+		operations, ok := paths[rt.pattern].(map[string]interface{})
+		if !ok {
+			operations = map[string]interface{}{}
+			paths[rt.pattern] = operations
+		}
+
+		operations[strings.ToLower(rt.method)] = map[string]interface{}{
+			"operationId": operationId,
+			"summary":     rt.summary,
+			"parameters":  params,
+			"responses": map[string]interface{}{
+				"200": jsonResponse("Successful response", string(rt.response.FullName())),
+				"default": jsonResponse(
+					"Error, the HTTP status is derived from the gRPC status code",
+					"google.rpc.Status",
+				),
+			},
+		}
+	}
+
+	doc := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "Movie Finder",
+			"version": "v1",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+			"securitySchemes": map[string]interface{}{
+				"bearer": map[string]interface{}{"type": "http", "scheme": "bearer"},
+				"apiKey": map[string]interface{}{"type": "apiKey", "in": "header", "name": apiKeyHeader},
+			},
+		},
+		"security": []interface{}{
+			map[string]interface{}{"bearer": []string{}},
+			map[string]interface{}{"apiKey": []string{}},
+		},
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func jsonResponse(description, schemaName string) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": schemaRef(schemaName),
+			},
+		},
+	}
+}
+
+func schemaRef(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+func addMessageSchema(schemas map[string]interface{}, md protoreflect.MessageDescriptor) {
+	name := string(md.FullName())
+	if _, ok := schemas[name]; ok {
+		return
+	}
+
+	properties := map[string]interface{}{}
+	schemas[name] = map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		schema := fieldSchema(schemas, fd)
+		if fd.IsList() {
+			schema = map[string]interface{}{"type": "array", "items": schema}
+		}
+		properties[fd.JSONName()] = schema
+	}
+}
+
+func fieldSchema(schemas map[string]interface{}, fd protoreflect.FieldDescriptor) map[string]interface{} {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]interface{}{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// protojson encodes 64 bit integers as strings
+		return map[string]interface{}{"type": "string", "format": "int64"}
+	case protoreflect.FloatKind:
+		return map[string]interface{}{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	case protoreflect.StringKind:
+		return map[string]interface{}{"type": "string"}
+	case protoreflect.BytesKind:
+		return map[string]interface{}{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		names := make([]string, 0, values.Len())
+		for i := 0; i < values.Len(); i++ {
+			names = append(names, string(values.Get(i).Name()))
+		}
+		return map[string]interface{}{"type": "string", "enum": names}
+	}
+
+	switch fd.Message().FullName() {
+	case "google.protobuf.Timestamp":
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case "google.protobuf.Duration", "google.protobuf.FieldMask":
+		return map[string]interface{}{"type": "string"}
+	}
+
+	addMessageSchema(schemas, fd.Message())
+	return schemaRef(string(fd.Message().FullName()))
+}
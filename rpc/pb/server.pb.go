@@ -9,6 +9,7 @@ package pb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -27,6 +28,11 @@ const (
 	GenrePeriodDetailsRequest_OP_LT GenrePeriodDetailsRequest_Operator = 0
 	GenrePeriodDetailsRequest_OP_EQ GenrePeriodDetailsRequest_Operator = 1
 	GenrePeriodDetailsRequest_OP_GT GenrePeriodDetailsRequest_Operator = 2
+	GenrePeriodDetailsRequest_OP_GE GenrePeriodDetailsRequest_Operator = 3
+	GenrePeriodDetailsRequest_OP_LE GenrePeriodDetailsRequest_Operator = 4
+	GenrePeriodDetailsRequest_OP_NE GenrePeriodDetailsRequest_Operator = 5
+	// revenue within [revenue, revenueMax], see the exclusive flags
+	GenrePeriodDetailsRequest_OP_BETWEEN GenrePeriodDetailsRequest_Operator = 6
 )
 
 // Enum value maps for GenrePeriodDetailsRequest_Operator.
@@ -35,11 +41,19 @@ var (
 		0: "OP_LT",
 		1: "OP_EQ",
 		2: "OP_GT",
+		3: "OP_GE",
+		4: "OP_LE",
+		5: "OP_NE",
+		6: "OP_BETWEEN",
 	}
 	GenrePeriodDetailsRequest_Operator_value = map[string]int32{
-		"OP_LT": 0,
-		"OP_EQ": 1,
-		"OP_GT": 2,
+		"OP_LT":      0,
+		"OP_EQ":      1,
+		"OP_GT":      2,
+		"OP_GE":      3,
+		"OP_LE":      4,
+		"OP_NE":      5,
+		"OP_BETWEEN": 6,
 	}
 )
 
@@ -70,6 +84,382 @@ func (GenrePeriodDetailsRequest_Operator) EnumDescriptor() ([]byte, []int) {
 	return file_pb_server_proto_rawDescGZIP(), []int{0, 0}
 }
 
+type GenrePeriodDetailsRequest_GenreMatch int32
+
+const (
+	GenrePeriodDetailsRequest_MATCH_ALL GenrePeriodDetailsRequest_GenreMatch = 0
+	GenrePeriodDetailsRequest_MATCH_ANY GenrePeriodDetailsRequest_GenreMatch = 1
+)
+
+// Enum value maps for GenrePeriodDetailsRequest_GenreMatch.
+var (
+	GenrePeriodDetailsRequest_GenreMatch_name = map[int32]string{
+		0: "MATCH_ALL",
+		1: "MATCH_ANY",
+	}
+	GenrePeriodDetailsRequest_GenreMatch_value = map[string]int32{
+		"MATCH_ALL": 0,
+		"MATCH_ANY": 1,
+	}
+)
+
+func (x GenrePeriodDetailsRequest_GenreMatch) Enum() *GenrePeriodDetailsRequest_GenreMatch {
+	p := new(GenrePeriodDetailsRequest_GenreMatch)
+	*p = x
+	return p
+}
+
+func (x GenrePeriodDetailsRequest_GenreMatch) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GenrePeriodDetailsRequest_GenreMatch) Descriptor() protoreflect.EnumDescriptor {
+	return file_pb_server_proto_enumTypes[1].Descriptor()
+}
+
+func (GenrePeriodDetailsRequest_GenreMatch) Type() protoreflect.EnumType {
+	return &file_pb_server_proto_enumTypes[1]
+}
+
+func (x GenrePeriodDetailsRequest_GenreMatch) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GenrePeriodDetailsRequest_GenreMatch.Descriptor instead.
+func (GenrePeriodDetailsRequest_GenreMatch) EnumDescriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{0, 1}
+}
+
+// ties are broken by TMDB id so that pages are stable
+type GenrePeriodDetailsRequest_SortField int32
+
+const (
+	GenrePeriodDetailsRequest_SORT_ID           GenrePeriodDetailsRequest_SortField = 0
+	GenrePeriodDetailsRequest_SORT_REVENUE      GenrePeriodDetailsRequest_SortField = 1
+	GenrePeriodDetailsRequest_SORT_RELEASE_DATE GenrePeriodDetailsRequest_SortField = 2
+	GenrePeriodDetailsRequest_SORT_TITLE        GenrePeriodDetailsRequest_SortField = 3
+	GenrePeriodDetailsRequest_SORT_VOTE_AVERAGE GenrePeriodDetailsRequest_SortField = 4
+	GenrePeriodDetailsRequest_SORT_PROFIT       GenrePeriodDetailsRequest_SortField = 5
+)
+
+// Enum value maps for GenrePeriodDetailsRequest_SortField.
+var (
+	GenrePeriodDetailsRequest_SortField_name = map[int32]string{
+		0: "SORT_ID",
+		1: "SORT_REVENUE",
+		2: "SORT_RELEASE_DATE",
+		3: "SORT_TITLE",
+		4: "SORT_VOTE_AVERAGE",
+		5: "SORT_PROFIT",
+	}
+	GenrePeriodDetailsRequest_SortField_value = map[string]int32{
+		"SORT_ID":           0,
+		"SORT_REVENUE":      1,
+		"SORT_RELEASE_DATE": 2,
+		"SORT_TITLE":        3,
+		"SORT_VOTE_AVERAGE": 4,
+		"SORT_PROFIT":       5,
+	}
+)
+
+func (x GenrePeriodDetailsRequest_SortField) Enum() *GenrePeriodDetailsRequest_SortField {
+	p := new(GenrePeriodDetailsRequest_SortField)
+	*p = x
+	return p
+}
+
+func (x GenrePeriodDetailsRequest_SortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GenrePeriodDetailsRequest_SortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_pb_server_proto_enumTypes[2].Descriptor()
+}
+
+func (GenrePeriodDetailsRequest_SortField) Type() protoreflect.EnumType {
+	return &file_pb_server_proto_enumTypes[2]
+}
+
+func (x GenrePeriodDetailsRequest_SortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GenrePeriodDetailsRequest_SortField.Descriptor instead.
+func (GenrePeriodDetailsRequest_SortField) EnumDescriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{0, 2}
+}
+
+// movies the matching movies are counted against in pct
+type GenrePeriodDetailsRequest_Denominator int32
+
+const (
+	// every movie released in the period
+	GenrePeriodDetailsRequest_DENOMINATOR_PERIOD GenrePeriodDetailsRequest_Denominator = 0
+	// the movies of the genres released in the period, filters or not
+	GenrePeriodDetailsRequest_DENOMINATOR_GENRE GenrePeriodDetailsRequest_Denominator = 1
+	// every movie released in the period which passes the filters
+	GenrePeriodDetailsRequest_DENOMINATOR_FILTERED GenrePeriodDetailsRequest_Denominator = 2
+)
+
+// Enum value maps for GenrePeriodDetailsRequest_Denominator.
+var (
+	GenrePeriodDetailsRequest_Denominator_name = map[int32]string{
+		0: "DENOMINATOR_PERIOD",
+		1: "DENOMINATOR_GENRE",
+		2: "DENOMINATOR_FILTERED",
+	}
+	GenrePeriodDetailsRequest_Denominator_value = map[string]int32{
+		"DENOMINATOR_PERIOD":   0,
+		"DENOMINATOR_GENRE":    1,
+		"DENOMINATOR_FILTERED": 2,
+	}
+)
+
+func (x GenrePeriodDetailsRequest_Denominator) Enum() *GenrePeriodDetailsRequest_Denominator {
+	p := new(GenrePeriodDetailsRequest_Denominator)
+	*p = x
+	return p
+}
+
+func (x GenrePeriodDetailsRequest_Denominator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GenrePeriodDetailsRequest_Denominator) Descriptor() protoreflect.EnumDescriptor {
+	return file_pb_server_proto_enumTypes[3].Descriptor()
+}
+
+func (GenrePeriodDetailsRequest_Denominator) Type() protoreflect.EnumType {
+	return &file_pb_server_proto_enumTypes[3]
+}
+
+func (x GenrePeriodDetailsRequest_Denominator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GenrePeriodDetailsRequest_Denominator.Descriptor instead.
+func (GenrePeriodDetailsRequest_Denominator) EnumDescriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{0, 3}
+}
+
+// how filters, including the revenue comparison, and the comparisons of
+// expr treat the revenue, budget and runtime TMDB reports as zero when it
+// doesn't know them, profit is unknown when either revenue or budget is
+type GenrePeriodDetailsRequest_UnknownValues int32
+
+const (
+	GenrePeriodDetailsRequest_UNKNOWN_AS_ZERO GenrePeriodDetailsRequest_UnknownValues = 0
+	// movies fail every filter on an unknown value
+	GenrePeriodDetailsRequest_UNKNOWN_EXCLUDED GenrePeriodDetailsRequest_UnknownValues = 1
+	// movies pass every filter on an unknown value
+	GenrePeriodDetailsRequest_UNKNOWN_INCLUDED GenrePeriodDetailsRequest_UnknownValues = 2
+)
+
+// Enum value maps for GenrePeriodDetailsRequest_UnknownValues.
+var (
+	GenrePeriodDetailsRequest_UnknownValues_name = map[int32]string{
+		0: "UNKNOWN_AS_ZERO",
+		1: "UNKNOWN_EXCLUDED",
+		2: "UNKNOWN_INCLUDED",
+	}
+	GenrePeriodDetailsRequest_UnknownValues_value = map[string]int32{
+		"UNKNOWN_AS_ZERO":  0,
+		"UNKNOWN_EXCLUDED": 1,
+		"UNKNOWN_INCLUDED": 2,
+	}
+)
+
+func (x GenrePeriodDetailsRequest_UnknownValues) Enum() *GenrePeriodDetailsRequest_UnknownValues {
+	p := new(GenrePeriodDetailsRequest_UnknownValues)
+	*p = x
+	return p
+}
+
+func (x GenrePeriodDetailsRequest_UnknownValues) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GenrePeriodDetailsRequest_UnknownValues) Descriptor() protoreflect.EnumDescriptor {
+	return file_pb_server_proto_enumTypes[4].Descriptor()
+}
+
+func (GenrePeriodDetailsRequest_UnknownValues) Type() protoreflect.EnumType {
+	return &file_pb_server_proto_enumTypes[4]
+}
+
+func (x GenrePeriodDetailsRequest_UnknownValues) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GenrePeriodDetailsRequest_UnknownValues.Descriptor instead.
+func (GenrePeriodDetailsRequest_UnknownValues) EnumDescriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{0, 4}
+}
+
+type AnomalyMsg_Kind int32
+
+const (
+	// revenue orders of magnitude away from the other matching movies,
+	// found with the robust z-score of the logarithm of the revenues
+	AnomalyMsg_ANOMALY_OUTLIER AnomalyMsg_Kind = 0
+	// revenue likely copied from the budget
+	AnomalyMsg_ANOMALY_REVENUE_EQUALS_BUDGET AnomalyMsg_Kind = 1
+	// revenue below 1000, likely entered in thousands or millions
+	AnomalyMsg_ANOMALY_TINY_REVENUE AnomalyMsg_Kind = 2
+)
+
+// Enum value maps for AnomalyMsg_Kind.
+var (
+	AnomalyMsg_Kind_name = map[int32]string{
+		0: "ANOMALY_OUTLIER",
+		1: "ANOMALY_REVENUE_EQUALS_BUDGET",
+		2: "ANOMALY_TINY_REVENUE",
+	}
+	AnomalyMsg_Kind_value = map[string]int32{
+		"ANOMALY_OUTLIER":               0,
+		"ANOMALY_REVENUE_EQUALS_BUDGET": 1,
+		"ANOMALY_TINY_REVENUE":          2,
+	}
+)
+
+func (x AnomalyMsg_Kind) Enum() *AnomalyMsg_Kind {
+	p := new(AnomalyMsg_Kind)
+	*p = x
+	return p
+}
+
+func (x AnomalyMsg_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AnomalyMsg_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_pb_server_proto_enumTypes[5].Descriptor()
+}
+
+func (AnomalyMsg_Kind) Type() protoreflect.EnumType {
+	return &file_pb_server_proto_enumTypes[5]
+}
+
+func (x AnomalyMsg_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AnomalyMsg_Kind.Descriptor instead.
+func (AnomalyMsg_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{5, 0}
+}
+
+// buckets follow the calendar, weeks start on Monday
+type GenreSeriesRequest_Granularity int32
+
+const (
+	GenreSeriesRequest_BY_MONTH   GenreSeriesRequest_Granularity = 0
+	GenreSeriesRequest_BY_WEEK    GenreSeriesRequest_Granularity = 1
+	GenreSeriesRequest_BY_QUARTER GenreSeriesRequest_Granularity = 2
+	GenreSeriesRequest_BY_YEAR    GenreSeriesRequest_Granularity = 3
+)
+
+// Enum value maps for GenreSeriesRequest_Granularity.
+var (
+	GenreSeriesRequest_Granularity_name = map[int32]string{
+		0: "BY_MONTH",
+		1: "BY_WEEK",
+		2: "BY_QUARTER",
+		3: "BY_YEAR",
+	}
+	GenreSeriesRequest_Granularity_value = map[string]int32{
+		"BY_MONTH":   0,
+		"BY_WEEK":    1,
+		"BY_QUARTER": 2,
+		"BY_YEAR":    3,
+	}
+)
+
+func (x GenreSeriesRequest_Granularity) Enum() *GenreSeriesRequest_Granularity {
+	p := new(GenreSeriesRequest_Granularity)
+	*p = x
+	return p
+}
+
+func (x GenreSeriesRequest_Granularity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GenreSeriesRequest_Granularity) Descriptor() protoreflect.EnumDescriptor {
+	return file_pb_server_proto_enumTypes[6].Descriptor()
+}
+
+func (GenreSeriesRequest_Granularity) Type() protoreflect.EnumType {
+	return &file_pb_server_proto_enumTypes[6]
+}
+
+func (x GenreSeriesRequest_Granularity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GenreSeriesRequest_Granularity.Descriptor instead.
+func (GenreSeriesRequest_Granularity) EnumDescriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{11, 0}
+}
+
+// movies are ranked from the highest to the lowest value
+type TopMoviesRequest_Metric int32
+
+const (
+	TopMoviesRequest_RANK_REVENUE TopMoviesRequest_Metric = 0
+	// revenue minus budget
+	TopMoviesRequest_RANK_PROFIT TopMoviesRequest_Metric = 1
+	// profit relative to the budget, 1.5 meaning 150%
+	TopMoviesRequest_RANK_ROI        TopMoviesRequest_Metric = 2
+	TopMoviesRequest_RANK_RATING     TopMoviesRequest_Metric = 3
+	TopMoviesRequest_RANK_POPULARITY TopMoviesRequest_Metric = 4
+)
+
+// Enum value maps for TopMoviesRequest_Metric.
+var (
+	TopMoviesRequest_Metric_name = map[int32]string{
+		0: "RANK_REVENUE",
+		1: "RANK_PROFIT",
+		2: "RANK_ROI",
+		3: "RANK_RATING",
+		4: "RANK_POPULARITY",
+	}
+	TopMoviesRequest_Metric_value = map[string]int32{
+		"RANK_REVENUE":    0,
+		"RANK_PROFIT":     1,
+		"RANK_ROI":        2,
+		"RANK_RATING":     3,
+		"RANK_POPULARITY": 4,
+	}
+)
+
+func (x TopMoviesRequest_Metric) Enum() *TopMoviesRequest_Metric {
+	p := new(TopMoviesRequest_Metric)
+	*p = x
+	return p
+}
+
+func (x TopMoviesRequest_Metric) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TopMoviesRequest_Metric) Descriptor() protoreflect.EnumDescriptor {
+	return file_pb_server_proto_enumTypes[7].Descriptor()
+}
+
+func (TopMoviesRequest_Metric) Type() protoreflect.EnumType {
+	return &file_pb_server_proto_enumTypes[7]
+}
+
+func (x TopMoviesRequest_Metric) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TopMoviesRequest_Metric.Descriptor instead.
+func (TopMoviesRequest_Metric) EnumDescriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{14, 0}
+}
+
 type GenrePeriodDetailsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -80,6 +470,42 @@ type GenrePeriodDetailsRequest struct {
 	EndDate              *timestamppb.Timestamp             `protobuf:"bytes,3,opt,name=endDate,proto3" json:"endDate,omitempty"`
 	Revenue              int64                              `protobuf:"varint,4,opt,name=revenue,proto3" json:"revenue,omitempty"`
 	RevenueCheckOperator GenrePeriodDetailsRequest_Operator `protobuf:"varint,5,opt,name=revenueCheckOperator,proto3,enum=movie.GenrePeriodDetailsRequest_Operator" json:"revenueCheckOperator,omitempty"`
+	// alternative to genreId, matched case-insensitively and tolerating typos
+	GenreName string `protobuf:"bytes,6,opt,name=genreName,proto3" json:"genreName,omitempty"`
+	// ISO 639-1 language of genre names, e.g. "de" or "pt-BR"
+	Language string `protobuf:"bytes,7,opt,name=language,proto3" json:"language,omitempty"`
+	// further genres, combined with genreId or genreName according to genreMatch
+	GenreIds   []int64                              `protobuf:"varint,8,rep,packed,name=genreIds,proto3" json:"genreIds,omitempty"`
+	GenreNames []string                             `protobuf:"bytes,9,rep,name=genreNames,proto3" json:"genreNames,omitempty"`
+	GenreMatch GenrePeriodDetailsRequest_GenreMatch `protobuf:"varint,10,opt,name=genreMatch,proto3,enum=movie.GenrePeriodDetailsRequest_GenreMatch" json:"genreMatch,omitempty"`
+	// movies having any of these genres are left out
+	ExcludedGenreIds   []int64  `protobuf:"varint,11,rep,packed,name=excludedGenreIds,proto3" json:"excludedGenreIds,omitempty"`
+	ExcludedGenreNames []string `protobuf:"bytes,12,rep,name=excludedGenreNames,proto3" json:"excludedGenreNames,omitempty"`
+	// upper bound of OP_BETWEEN, both bounds are inclusive unless flagged
+	RevenueMax          int64 `protobuf:"varint,13,opt,name=revenueMax,proto3" json:"revenueMax,omitempty"`
+	RevenueMinExclusive bool  `protobuf:"varint,14,opt,name=revenueMinExclusive,proto3" json:"revenueMinExclusive,omitempty"`
+	RevenueMaxExclusive bool  `protobuf:"varint,15,opt,name=revenueMaxExclusive,proto3" json:"revenueMaxExclusive,omitempty"`
+	// movies must pass every filter, revenue and its operator are ignored
+	// and must be left unset when filters are given
+	Filters []*MovieFilter `protobuf:"bytes,16,rep,name=filters,proto3" json:"filters,omitempty"`
+	// boolean expression movies must satisfy as well, e.g.
+	// revenue > 2 * budget && "US" in production_countries
+	Expression string                              `protobuf:"bytes,17,opt,name=expression,proto3" json:"expression,omitempty"`
+	SortBy     GenrePeriodDetailsRequest_SortField `protobuf:"varint,18,opt,name=sortBy,proto3,enum=movie.GenrePeriodDetailsRequest_SortField" json:"sortBy,omitempty"`
+	Descending bool                                `protobuf:"varint,19,opt,name=descending,proto3" json:"descending,omitempty"`
+	// zero returns every movie, otherwise pass the previous nextPageToken
+	PageSize  int32  `protobuf:"varint,20,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken string `protobuf:"bytes,21,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	// MovieMsg fields returned for every movie, e.g. "title,revenue,genres",
+	// all of them when unset
+	MovieMask *fieldmaskpb.FieldMask `protobuf:"bytes,22,opt,name=movieMask,proto3" json:"movieMask,omitempty"`
+	// aggregates every matching movie, not only the page returned
+	IncludeStats bool                                  `protobuf:"varint,23,opt,name=includeStats,proto3" json:"includeStats,omitempty"`
+	Denominator  GenrePeriodDetailsRequest_Denominator `protobuf:"varint,24,opt,name=denominator,proto3,enum=movie.GenrePeriodDetailsRequest_Denominator" json:"denominator,omitempty"`
+	// flags the movies of the page whose revenue looks wrong, looking at
+	// every matching movie
+	DetectAnomalies bool                                    `protobuf:"varint,25,opt,name=detectAnomalies,proto3" json:"detectAnomalies,omitempty"`
+	UnknownValues   GenrePeriodDetailsRequest_UnknownValues `protobuf:"varint,26,opt,name=unknownValues,proto3,enum=movie.GenrePeriodDetailsRequest_UnknownValues" json:"unknownValues,omitempty"`
 }
 
 func (x *GenrePeriodDetailsRequest) Reset() {
@@ -149,105 +575,193 @@ func (x *GenrePeriodDetailsRequest) GetRevenueCheckOperator() GenrePeriodDetails
 	return GenrePeriodDetailsRequest_OP_LT
 }
 
-type GenrePeriodDetailsReply struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *GenrePeriodDetailsRequest) GetGenreName() string {
+	if x != nil {
+		return x.GenreName
+	}
+	return ""
+}
 
-	GenreId int64       `protobuf:"varint,1,opt,name=genreId,proto3" json:"genreId,omitempty"`
-	Name    string      `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Pct     float32     `protobuf:"fixed32,3,opt,name=pct,proto3" json:"pct,omitempty"`
-	Movies  []*MovieMsg `protobuf:"bytes,4,rep,name=movies,proto3" json:"movies,omitempty"`
+func (x *GenrePeriodDetailsRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
 }
 
-func (x *GenrePeriodDetailsReply) Reset() {
-	*x = GenrePeriodDetailsReply{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_pb_server_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *GenrePeriodDetailsRequest) GetGenreIds() []int64 {
+	if x != nil {
+		return x.GenreIds
 	}
+	return nil
 }
 
-func (x *GenrePeriodDetailsReply) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GenrePeriodDetailsRequest) GetGenreNames() []string {
+	if x != nil {
+		return x.GenreNames
+	}
+	return nil
 }
 
-func (*GenrePeriodDetailsReply) ProtoMessage() {}
+func (x *GenrePeriodDetailsRequest) GetGenreMatch() GenrePeriodDetailsRequest_GenreMatch {
+	if x != nil {
+		return x.GenreMatch
+	}
+	return GenrePeriodDetailsRequest_MATCH_ALL
+}
 
-func (x *GenrePeriodDetailsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_server_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *GenrePeriodDetailsRequest) GetExcludedGenreIds() []int64 {
+	if x != nil {
+		return x.ExcludedGenreIds
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use GenrePeriodDetailsReply.ProtoReflect.Descriptor instead.
-func (*GenrePeriodDetailsReply) Descriptor() ([]byte, []int) {
-	return file_pb_server_proto_rawDescGZIP(), []int{1}
+func (x *GenrePeriodDetailsRequest) GetExcludedGenreNames() []string {
+	if x != nil {
+		return x.ExcludedGenreNames
+	}
+	return nil
 }
 
-func (x *GenrePeriodDetailsReply) GetGenreId() int64 {
+func (x *GenrePeriodDetailsRequest) GetRevenueMax() int64 {
 	if x != nil {
-		return x.GenreId
+		return x.RevenueMax
 	}
 	return 0
 }
 
-func (x *GenrePeriodDetailsReply) GetName() string {
+func (x *GenrePeriodDetailsRequest) GetRevenueMinExclusive() bool {
 	if x != nil {
-		return x.Name
+		return x.RevenueMinExclusive
 	}
-	return ""
+	return false
 }
 
-func (x *GenrePeriodDetailsReply) GetPct() float32 {
+func (x *GenrePeriodDetailsRequest) GetRevenueMaxExclusive() bool {
 	if x != nil {
-		return x.Pct
+		return x.RevenueMaxExclusive
 	}
-	return 0
+	return false
 }
 
-func (x *GenrePeriodDetailsReply) GetMovies() []*MovieMsg {
+func (x *GenrePeriodDetailsRequest) GetFilters() []*MovieFilter {
 	if x != nil {
-		return x.Movies
+		return x.Filters
 	}
 	return nil
 }
 
-type MovieMsg struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Id          int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title       string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	ReleaseDate string `protobuf:"bytes,3,opt,name=releaseDate,proto3" json:"releaseDate,omitempty"`
-	Revenue     int64  `protobuf:"varint,4,opt,name=revenue,proto3" json:"revenue,omitempty"`
+func (x *GenrePeriodDetailsRequest) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
 }
 
-func (x *MovieMsg) Reset() {
-	*x = MovieMsg{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_pb_server_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *GenrePeriodDetailsRequest) GetSortBy() GenrePeriodDetailsRequest_SortField {
+	if x != nil {
+		return x.SortBy
 	}
+	return GenrePeriodDetailsRequest_SORT_ID
 }
 
-func (x *MovieMsg) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GenrePeriodDetailsRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
 }
 
-func (*MovieMsg) ProtoMessage() {}
+func (x *GenrePeriodDetailsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
 
-func (x *MovieMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_server_proto_msgTypes[2]
+func (x *GenrePeriodDetailsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GenrePeriodDetailsRequest) GetMovieMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.MovieMask
+	}
+	return nil
+}
+
+func (x *GenrePeriodDetailsRequest) GetIncludeStats() bool {
+	if x != nil {
+		return x.IncludeStats
+	}
+	return false
+}
+
+func (x *GenrePeriodDetailsRequest) GetDenominator() GenrePeriodDetailsRequest_Denominator {
+	if x != nil {
+		return x.Denominator
+	}
+	return GenrePeriodDetailsRequest_DENOMINATOR_PERIOD
+}
+
+func (x *GenrePeriodDetailsRequest) GetDetectAnomalies() bool {
+	if x != nil {
+		return x.DetectAnomalies
+	}
+	return false
+}
+
+func (x *GenrePeriodDetailsRequest) GetUnknownValues() GenrePeriodDetailsRequest_UnknownValues {
+	if x != nil {
+		return x.UnknownValues
+	}
+	return GenrePeriodDetailsRequest_UNKNOWN_AS_ZERO
+}
+
+type MovieFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// revenue, budget, profit, runtime, vote_average, vote_count, popularity,
+	// original_language, status or adult
+	Field    string                             `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Operator GenrePeriodDetailsRequest_Operator `protobuf:"varint,2,opt,name=operator,proto3,enum=movie.GenrePeriodDetailsRequest_Operator" json:"operator,omitempty"`
+	// numeric fields take a number, original_language and status a text and
+	// adult a flag, text and flag only support OP_EQ and OP_NE
+	//
+	// Types that are assignable to Value:
+	//	*MovieFilter_Number
+	//	*MovieFilter_Text
+	//	*MovieFilter_Flag
+	Value isMovieFilter_Value `protobuf_oneof:"value"`
+	// upper bound of OP_BETWEEN, both bounds are inclusive unless flagged
+	Max          float64 `protobuf:"fixed64,6,opt,name=max,proto3" json:"max,omitempty"`
+	MinExclusive bool    `protobuf:"varint,7,opt,name=minExclusive,proto3" json:"minExclusive,omitempty"`
+	MaxExclusive bool    `protobuf:"varint,8,opt,name=maxExclusive,proto3" json:"maxExclusive,omitempty"`
+}
+
+func (x *MovieFilter) Reset() {
+	*x = MovieFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MovieFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovieFilter) ProtoMessage() {}
+
+func (x *MovieFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -258,161 +772,3190 @@ func (x *MovieMsg) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MovieMsg.ProtoReflect.Descriptor instead.
-func (*MovieMsg) Descriptor() ([]byte, []int) {
+// Deprecated: Use MovieFilter.ProtoReflect.Descriptor instead.
+func (*MovieFilter) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MovieFilter) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *MovieFilter) GetOperator() GenrePeriodDetailsRequest_Operator {
+	if x != nil {
+		return x.Operator
+	}
+	return GenrePeriodDetailsRequest_OP_LT
+}
+
+func (m *MovieFilter) GetValue() isMovieFilter_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (x *MovieFilter) GetNumber() float64 {
+	if x, ok := x.GetValue().(*MovieFilter_Number); ok {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *MovieFilter) GetText() string {
+	if x, ok := x.GetValue().(*MovieFilter_Text); ok {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *MovieFilter) GetFlag() bool {
+	if x, ok := x.GetValue().(*MovieFilter_Flag); ok {
+		return x.Flag
+	}
+	return false
+}
+
+func (x *MovieFilter) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *MovieFilter) GetMinExclusive() bool {
+	if x != nil {
+		return x.MinExclusive
+	}
+	return false
+}
+
+func (x *MovieFilter) GetMaxExclusive() bool {
+	if x != nil {
+		return x.MaxExclusive
+	}
+	return false
+}
+
+type isMovieFilter_Value interface {
+	isMovieFilter_Value()
+}
+
+type MovieFilter_Number struct {
+	Number float64 `protobuf:"fixed64,3,opt,name=number,proto3,oneof"`
+}
+
+type MovieFilter_Text struct {
+	Text string `protobuf:"bytes,4,opt,name=text,proto3,oneof"`
+}
+
+type MovieFilter_Flag struct {
+	Flag bool `protobuf:"varint,5,opt,name=flag,proto3,oneof"`
+}
+
+func (*MovieFilter_Number) isMovieFilter_Value() {}
+
+func (*MovieFilter_Text) isMovieFilter_Value() {}
+
+func (*MovieFilter_Flag) isMovieFilter_Value() {}
+
+type GenrePeriodDetailsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// only set when a single genre was requested
+	GenreId        int64                                `protobuf:"varint,1,opt,name=genreId,proto3" json:"genreId,omitempty"`
+	Name           string                               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Pct            float32                              `protobuf:"fixed32,3,opt,name=pct,proto3" json:"pct,omitempty"`
+	Movies         []*MovieMsg                          `protobuf:"bytes,4,rep,name=movies,proto3" json:"movies,omitempty"`
+	Genres         []*GenreMsg                          `protobuf:"bytes,5,rep,name=genres,proto3" json:"genres,omitempty"`
+	ExcludedGenres []*GenreMsg                          `protobuf:"bytes,6,rep,name=excludedGenres,proto3" json:"excludedGenres,omitempty"`
+	GenreMatch     GenrePeriodDetailsRequest_GenreMatch `protobuf:"varint,7,opt,name=genreMatch,proto3,enum=movie.GenrePeriodDetailsRequest_GenreMatch" json:"genreMatch,omitempty"`
+	// empty on the last page
+	NextPageToken string `protobuf:"bytes,8,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	// movies matching the query across all pages
+	TotalMovies int64 `protobuf:"varint,9,opt,name=totalMovies,proto3" json:"totalMovies,omitempty"`
+	// only set when includeStats was requested
+	Stats *MovieStats `protobuf:"bytes,10,opt,name=stats,proto3" json:"stats,omitempty"`
+	// pct is totalMovies relative to denominatorMovies, counted as requested
+	Denominator       GenrePeriodDetailsRequest_Denominator `protobuf:"varint,11,opt,name=denominator,proto3,enum=movie.GenrePeriodDetailsRequest_Denominator" json:"denominator,omitempty"`
+	DenominatorMovies int64                                 `protobuf:"varint,12,opt,name=denominatorMovies,proto3" json:"denominatorMovies,omitempty"`
+	// only set when detectAnomalies was requested, flagged movies of the page
+	// in the order of movies
+	Anomalies []*MovieAnomalies `protobuf:"bytes,13,rep,name=anomalies,proto3" json:"anomalies,omitempty"`
+	// flagged movies across all pages
+	AnomalousMovies int64 `protobuf:"varint,14,opt,name=anomalousMovies,proto3" json:"anomalousMovies,omitempty"`
+	// movies of the genres checked against the filters whose values were
+	// unknown
+	UnknownValues *UnknownValueCounts `protobuf:"bytes,15,opt,name=unknownValues,proto3" json:"unknownValues,omitempty"`
+}
+
+func (x *GenrePeriodDetailsReply) Reset() {
+	*x = GenrePeriodDetailsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenrePeriodDetailsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenrePeriodDetailsReply) ProtoMessage() {}
+
+func (x *GenrePeriodDetailsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenrePeriodDetailsReply.ProtoReflect.Descriptor instead.
+func (*GenrePeriodDetailsReply) Descriptor() ([]byte, []int) {
 	return file_pb_server_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *MovieMsg) GetId() int64 {
+func (x *GenrePeriodDetailsReply) GetGenreId() int64 {
 	if x != nil {
-		return x.Id
+		return x.GenreId
 	}
 	return 0
 }
 
-func (x *MovieMsg) GetTitle() string {
+func (x *GenrePeriodDetailsReply) GetName() string {
 	if x != nil {
-		return x.Title
+		return x.Name
 	}
 	return ""
 }
 
-func (x *MovieMsg) GetReleaseDate() string {
+func (x *GenrePeriodDetailsReply) GetPct() float32 {
 	if x != nil {
-		return x.ReleaseDate
+		return x.Pct
+	}
+	return 0
+}
+
+func (x *GenrePeriodDetailsReply) GetMovies() []*MovieMsg {
+	if x != nil {
+		return x.Movies
+	}
+	return nil
+}
+
+func (x *GenrePeriodDetailsReply) GetGenres() []*GenreMsg {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *GenrePeriodDetailsReply) GetExcludedGenres() []*GenreMsg {
+	if x != nil {
+		return x.ExcludedGenres
+	}
+	return nil
+}
+
+func (x *GenrePeriodDetailsReply) GetGenreMatch() GenrePeriodDetailsRequest_GenreMatch {
+	if x != nil {
+		return x.GenreMatch
+	}
+	return GenrePeriodDetailsRequest_MATCH_ALL
+}
+
+func (x *GenrePeriodDetailsReply) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
 	}
 	return ""
 }
 
-func (x *MovieMsg) GetRevenue() int64 {
+func (x *GenrePeriodDetailsReply) GetTotalMovies() int64 {
 	if x != nil {
-		return x.Revenue
+		return x.TotalMovies
 	}
 	return 0
 }
 
-var File_pb_server_proto protoreflect.FileDescriptor
+func (x *GenrePeriodDetailsReply) GetStats() *MovieStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
 
-var file_pb_server_proto_rawDesc = []byte{
-	0x0a, 0x0f, 0x70, 0x62, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x05, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xcb, 0x02, 0x0a, 0x19, 0x47, 0x65,
-	0x6e, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x65, 0x6e, 0x72, 0x65,
-	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x49,
-	0x64, 0x12, 0x38, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65, 0x12, 0x34, 0x0a, 0x07, 0x65,
-	0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74,
-	0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x07, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x12, 0x5d, 0x0a, 0x14, 0x72,
-	0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4f, 0x70, 0x65, 0x72, 0x61,
-	0x74, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x6d, 0x6f, 0x76, 0x69,
-	0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x44, 0x65, 0x74,
-	0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4f, 0x70, 0x65, 0x72,
-	0x61, 0x74, 0x6f, 0x72, 0x52, 0x14, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x43, 0x68, 0x65,
-	0x63, 0x6b, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x22, 0x2b, 0x0a, 0x08, 0x4f, 0x70,
-	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x50, 0x5f, 0x4c, 0x54, 0x10,
-	0x00, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x50, 0x5f, 0x45, 0x51, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05,
-	0x4f, 0x50, 0x5f, 0x47, 0x54, 0x10, 0x02, 0x22, 0x82, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x6e, 0x72,
-	0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x49, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x03,
-	0x70, 0x63, 0x74, 0x12, 0x27, 0x0a, 0x06, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x4d, 0x6f, 0x76, 0x69,
-	0x65, 0x4d, 0x73, 0x67, 0x52, 0x06, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x22, 0x6c, 0x0a, 0x08,
-	0x4d, 0x6f, 0x76, 0x69, 0x65, 0x4d, 0x73, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20,
-	0x0a, 0x0b, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x44, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x44, 0x61, 0x74, 0x65,
-	0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x07, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x32, 0x66, 0x0a, 0x05, 0x4d, 0x6f,
-	0x76, 0x69, 0x65, 0x12, 0x5d, 0x0a, 0x17, 0x46, 0x65, 0x74, 0x63, 0x68, 0x47, 0x65, 0x6e, 0x72,
-	0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x20,
-	0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69,
-	0x6f, 0x64, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1e, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x50, 0x65,
-	0x72, 0x69, 0x6f, 0x64, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x22, 0x00, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x61, 0x66, 0x66, 0x61, 0x6e, 0x73, 0x68, 0x61, 0x68, 0x69, 0x64, 0x2f, 0x63, 0x6f, 0x6e,
-	0x76, 0x6f, 0x6c, 0x75, 0x74, 0x65, 0x64, 0x2d, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2d, 0x66, 0x69,
-	0x6e, 0x64, 0x65, 0x72, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+func (x *GenrePeriodDetailsReply) GetDenominator() GenrePeriodDetailsRequest_Denominator {
+	if x != nil {
+		return x.Denominator
+	}
+	return GenrePeriodDetailsRequest_DENOMINATOR_PERIOD
 }
 
-var (
-	file_pb_server_proto_rawDescOnce sync.Once
-	file_pb_server_proto_rawDescData = file_pb_server_proto_rawDesc
-)
+func (x *GenrePeriodDetailsReply) GetDenominatorMovies() int64 {
+	if x != nil {
+		return x.DenominatorMovies
+	}
+	return 0
+}
 
-func file_pb_server_proto_rawDescGZIP() []byte {
-	file_pb_server_proto_rawDescOnce.Do(func() {
-		file_pb_server_proto_rawDescData = protoimpl.X.CompressGZIP(file_pb_server_proto_rawDescData)
-	})
-	return file_pb_server_proto_rawDescData
+func (x *GenrePeriodDetailsReply) GetAnomalies() []*MovieAnomalies {
+	if x != nil {
+		return x.Anomalies
+	}
+	return nil
 }
 
-var file_pb_server_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_pb_server_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
-var file_pb_server_proto_goTypes = []interface{}{
-	(GenrePeriodDetailsRequest_Operator)(0), // 0: movie.GenrePeriodDetailsRequest.Operator
-	(*GenrePeriodDetailsRequest)(nil),       // 1: movie.GenrePeriodDetailsRequest
-	(*GenrePeriodDetailsReply)(nil),         // 2: movie.GenrePeriodDetailsReply
-	(*MovieMsg)(nil),                        // 3: movie.MovieMsg
-	(*timestamppb.Timestamp)(nil),           // 4: google.protobuf.Timestamp
+func (x *GenrePeriodDetailsReply) GetAnomalousMovies() int64 {
+	if x != nil {
+		return x.AnomalousMovies
+	}
+	return 0
 }
-var file_pb_server_proto_depIdxs = []int32{
-	4, // 0: movie.GenrePeriodDetailsRequest.startDate:type_name -> google.protobuf.Timestamp
-	4, // 1: movie.GenrePeriodDetailsRequest.endDate:type_name -> google.protobuf.Timestamp
-	0, // 2: movie.GenrePeriodDetailsRequest.revenueCheckOperator:type_name -> movie.GenrePeriodDetailsRequest.Operator
-	3, // 3: movie.GenrePeriodDetailsReply.movies:type_name -> movie.MovieMsg
-	1, // 4: movie.Movie.FetchGenrePeriodDetails:input_type -> movie.GenrePeriodDetailsRequest
-	2, // 5: movie.Movie.FetchGenrePeriodDetails:output_type -> movie.GenrePeriodDetailsReply
-	5, // [5:6] is the sub-list for method output_type
-	4, // [4:5] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+
+func (x *GenrePeriodDetailsReply) GetUnknownValues() *UnknownValueCounts {
+	if x != nil {
+		return x.UnknownValues
+	}
+	return nil
 }
 
-func init() { file_pb_server_proto_init() }
-func file_pb_server_proto_init() {
-	if File_pb_server_proto != nil {
-		return
+type UnknownValueCounts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Revenue int64 `protobuf:"varint,1,opt,name=revenue,proto3" json:"revenue,omitempty"`
+	Budget  int64 `protobuf:"varint,2,opt,name=budget,proto3" json:"budget,omitempty"`
+	Runtime int64 `protobuf:"varint,3,opt,name=runtime,proto3" json:"runtime,omitempty"`
+}
+
+func (x *UnknownValueCounts) Reset() {
+	*x = UnknownValueCounts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_pb_server_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GenrePeriodDetailsRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+}
+
+func (x *UnknownValueCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnknownValueCounts) ProtoMessage() {}
+
+func (x *UnknownValueCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_pb_server_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GenrePeriodDetailsReply); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnknownValueCounts.ProtoReflect.Descriptor instead.
+func (*UnknownValueCounts) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UnknownValueCounts) GetRevenue() int64 {
+	if x != nil {
+		return x.Revenue
+	}
+	return 0
+}
+
+func (x *UnknownValueCounts) GetBudget() int64 {
+	if x != nil {
+		return x.Budget
+	}
+	return 0
+}
+
+func (x *UnknownValueCounts) GetRuntime() int64 {
+	if x != nil {
+		return x.Runtime
+	}
+	return 0
+}
+
+type MovieAnomalies struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MovieId   int64         `protobuf:"varint,1,opt,name=movieId,proto3" json:"movieId,omitempty"`
+	Anomalies []*AnomalyMsg `protobuf:"bytes,2,rep,name=anomalies,proto3" json:"anomalies,omitempty"`
+}
+
+func (x *MovieAnomalies) Reset() {
+	*x = MovieAnomalies{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MovieAnomalies) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovieAnomalies) ProtoMessage() {}
+
+func (x *MovieAnomalies) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovieAnomalies.ProtoReflect.Descriptor instead.
+func (*MovieAnomalies) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MovieAnomalies) GetMovieId() int64 {
+	if x != nil {
+		return x.MovieId
+	}
+	return 0
+}
+
+func (x *MovieAnomalies) GetAnomalies() []*AnomalyMsg {
+	if x != nil {
+		return x.Anomalies
+	}
+	return nil
+}
+
+type AnomalyMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind   AnomalyMsg_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=movie.AnomalyMsg_Kind" json:"kind,omitempty"`
+	Reason string          `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *AnomalyMsg) Reset() {
+	*x = AnomalyMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AnomalyMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnomalyMsg) ProtoMessage() {}
+
+func (x *AnomalyMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnomalyMsg.ProtoReflect.Descriptor instead.
+func (*AnomalyMsg) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AnomalyMsg) GetKind() AnomalyMsg_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return AnomalyMsg_ANOMALY_OUTLIER
+}
+
+func (x *AnomalyMsg) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Movies with an unknown (zero) revenue or budget are left out of every
+// aggregate and only counted in unknownRevenue and unknownBudget
+type MovieStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// movies the aggregates cover
+	Count         int64   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	TotalRevenue  int64   `protobuf:"varint,2,opt,name=totalRevenue,proto3" json:"totalRevenue,omitempty"`
+	MeanRevenue   float64 `protobuf:"fixed64,3,opt,name=meanRevenue,proto3" json:"meanRevenue,omitempty"`
+	MedianRevenue float64 `protobuf:"fixed64,4,opt,name=medianRevenue,proto3" json:"medianRevenue,omitempty"`
+	P90Revenue    float64 `protobuf:"fixed64,5,opt,name=p90Revenue,proto3" json:"p90Revenue,omitempty"`
+	TotalBudget   int64   `protobuf:"varint,6,opt,name=totalBudget,proto3" json:"totalBudget,omitempty"`
+	// total profit relative to the total budget, 1.5 meaning 150%
+	Roi            float64 `protobuf:"fixed64,7,opt,name=roi,proto3" json:"roi,omitempty"`
+	AverageRuntime float64 `protobuf:"fixed64,8,opt,name=averageRuntime,proto3" json:"averageRuntime,omitempty"`
+	AverageRating  float64 `protobuf:"fixed64,9,opt,name=averageRating,proto3" json:"averageRating,omitempty"`
+	UnknownRevenue int64   `protobuf:"varint,10,opt,name=unknownRevenue,proto3" json:"unknownRevenue,omitempty"`
+	UnknownBudget  int64   `protobuf:"varint,11,opt,name=unknownBudget,proto3" json:"unknownBudget,omitempty"`
+}
+
+func (x *MovieStats) Reset() {
+	*x = MovieStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MovieStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovieStats) ProtoMessage() {}
+
+func (x *MovieStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovieStats.ProtoReflect.Descriptor instead.
+func (*MovieStats) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MovieStats) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *MovieStats) GetTotalRevenue() int64 {
+	if x != nil {
+		return x.TotalRevenue
+	}
+	return 0
+}
+
+func (x *MovieStats) GetMeanRevenue() float64 {
+	if x != nil {
+		return x.MeanRevenue
+	}
+	return 0
+}
+
+func (x *MovieStats) GetMedianRevenue() float64 {
+	if x != nil {
+		return x.MedianRevenue
+	}
+	return 0
+}
+
+func (x *MovieStats) GetP90Revenue() float64 {
+	if x != nil {
+		return x.P90Revenue
+	}
+	return 0
+}
+
+func (x *MovieStats) GetTotalBudget() int64 {
+	if x != nil {
+		return x.TotalBudget
+	}
+	return 0
+}
+
+func (x *MovieStats) GetRoi() float64 {
+	if x != nil {
+		return x.Roi
+	}
+	return 0
+}
+
+func (x *MovieStats) GetAverageRuntime() float64 {
+	if x != nil {
+		return x.AverageRuntime
+	}
+	return 0
+}
+
+func (x *MovieStats) GetAverageRating() float64 {
+	if x != nil {
+		return x.AverageRating
+	}
+	return 0
+}
+
+func (x *MovieStats) GetUnknownRevenue() int64 {
+	if x != nil {
+		return x.UnknownRevenue
+	}
+	return 0
+}
+
+func (x *MovieStats) GetUnknownBudget() int64 {
+	if x != nil {
+		return x.UnknownBudget
+	}
+	return 0
+}
+
+type MovieMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                  int64          `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title               string         `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	ReleaseDate         string         `protobuf:"bytes,3,opt,name=releaseDate,proto3" json:"releaseDate,omitempty"`
+	Revenue             int64          `protobuf:"varint,4,opt,name=revenue,proto3" json:"revenue,omitempty"`
+	Budget              int64          `protobuf:"varint,5,opt,name=budget,proto3" json:"budget,omitempty"`
+	Runtime             int32          `protobuf:"varint,6,opt,name=runtime,proto3" json:"runtime,omitempty"`
+	Genres              []*GenreMsg    `protobuf:"bytes,7,rep,name=genres,proto3" json:"genres,omitempty"`
+	VoteAverage         float32        `protobuf:"fixed32,8,opt,name=voteAverage,proto3" json:"voteAverage,omitempty"`
+	VoteCount           int64          `protobuf:"varint,9,opt,name=voteCount,proto3" json:"voteCount,omitempty"`
+	Popularity          float32        `protobuf:"fixed32,10,opt,name=popularity,proto3" json:"popularity,omitempty"`
+	PosterPath          string         `protobuf:"bytes,11,opt,name=posterPath,proto3" json:"posterPath,omitempty"`
+	BackdropPath        string         `protobuf:"bytes,12,opt,name=backdropPath,proto3" json:"backdropPath,omitempty"`
+	Overview            string         `protobuf:"bytes,13,opt,name=overview,proto3" json:"overview,omitempty"`
+	ProductionCompanies []*CompanyMsg  `protobuf:"bytes,14,rep,name=productionCompanies,proto3" json:"productionCompanies,omitempty"`
+	ProductionCountries []*CountryMsg  `protobuf:"bytes,15,rep,name=productionCountries,proto3" json:"productionCountries,omitempty"`
+	SpokenLanguages     []*LanguageMsg `protobuf:"bytes,16,rep,name=spokenLanguages,proto3" json:"spokenLanguages,omitempty"`
+	ImdbId              string         `protobuf:"bytes,17,opt,name=imdbId,proto3" json:"imdbId,omitempty"`
+}
+
+func (x *MovieMsg) Reset() {
+	*x = MovieMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MovieMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovieMsg) ProtoMessage() {}
+
+func (x *MovieMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovieMsg.ProtoReflect.Descriptor instead.
+func (*MovieMsg) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MovieMsg) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MovieMsg) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *MovieMsg) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+func (x *MovieMsg) GetRevenue() int64 {
+	if x != nil {
+		return x.Revenue
+	}
+	return 0
+}
+
+func (x *MovieMsg) GetBudget() int64 {
+	if x != nil {
+		return x.Budget
+	}
+	return 0
+}
+
+func (x *MovieMsg) GetRuntime() int32 {
+	if x != nil {
+		return x.Runtime
+	}
+	return 0
+}
+
+func (x *MovieMsg) GetGenres() []*GenreMsg {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *MovieMsg) GetVoteAverage() float32 {
+	if x != nil {
+		return x.VoteAverage
+	}
+	return 0
+}
+
+func (x *MovieMsg) GetVoteCount() int64 {
+	if x != nil {
+		return x.VoteCount
+	}
+	return 0
+}
+
+func (x *MovieMsg) GetPopularity() float32 {
+	if x != nil {
+		return x.Popularity
+	}
+	return 0
+}
+
+func (x *MovieMsg) GetPosterPath() string {
+	if x != nil {
+		return x.PosterPath
+	}
+	return ""
+}
+
+func (x *MovieMsg) GetBackdropPath() string {
+	if x != nil {
+		return x.BackdropPath
+	}
+	return ""
+}
+
+func (x *MovieMsg) GetOverview() string {
+	if x != nil {
+		return x.Overview
+	}
+	return ""
+}
+
+func (x *MovieMsg) GetProductionCompanies() []*CompanyMsg {
+	if x != nil {
+		return x.ProductionCompanies
+	}
+	return nil
+}
+
+func (x *MovieMsg) GetProductionCountries() []*CountryMsg {
+	if x != nil {
+		return x.ProductionCountries
+	}
+	return nil
+}
+
+func (x *MovieMsg) GetSpokenLanguages() []*LanguageMsg {
+	if x != nil {
+		return x.SpokenLanguages
+	}
+	return nil
+}
+
+func (x *MovieMsg) GetImdbId() string {
+	if x != nil {
+		return x.ImdbId
+	}
+	return ""
+}
+
+type CompanyMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	LogoPath      string `protobuf:"bytes,3,opt,name=logoPath,proto3" json:"logoPath,omitempty"`
+	OriginCountry string `protobuf:"bytes,4,opt,name=originCountry,proto3" json:"originCountry,omitempty"`
+}
+
+func (x *CompanyMsg) Reset() {
+	*x = CompanyMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompanyMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanyMsg) ProtoMessage() {}
+
+func (x *CompanyMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanyMsg.ProtoReflect.Descriptor instead.
+func (*CompanyMsg) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CompanyMsg) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CompanyMsg) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CompanyMsg) GetLogoPath() string {
+	if x != nil {
+		return x.LogoPath
+	}
+	return ""
+}
+
+func (x *CompanyMsg) GetOriginCountry() string {
+	if x != nil {
+		return x.OriginCountry
+	}
+	return ""
+}
+
+type CountryMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ISO 3166-1 code
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *CountryMsg) Reset() {
+	*x = CountryMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountryMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountryMsg) ProtoMessage() {}
+
+func (x *CountryMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountryMsg.ProtoReflect.Descriptor instead.
+func (*CountryMsg) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CountryMsg) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CountryMsg) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type LanguageMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ISO 639-1 code
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *LanguageMsg) Reset() {
+	*x = LanguageMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LanguageMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LanguageMsg) ProtoMessage() {}
+
+func (x *LanguageMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LanguageMsg.ProtoReflect.Descriptor instead.
+func (*LanguageMsg) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *LanguageMsg) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *LanguageMsg) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GenreSeriesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// genres, period and filters of the series, sorting, paging, movieMask,
+	// includeStats and denominator don't apply, pct is relative to every
+	// movie released in the bucket
+	Query       *GenrePeriodDetailsRequest     `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Granularity GenreSeriesRequest_Granularity `protobuf:"varint,2,opt,name=granularity,proto3,enum=movie.GenreSeriesRequest_Granularity" json:"granularity,omitempty"`
+}
+
+func (x *GenreSeriesRequest) Reset() {
+	*x = GenreSeriesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenreSeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenreSeriesRequest) ProtoMessage() {}
+
+func (x *GenreSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenreSeriesRequest.ProtoReflect.Descriptor instead.
+func (*GenreSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GenreSeriesRequest) GetQuery() *GenrePeriodDetailsRequest {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *GenreSeriesRequest) GetGranularity() GenreSeriesRequest_Granularity {
+	if x != nil {
+		return x.Granularity
+	}
+	return GenreSeriesRequest_BY_MONTH
+}
+
+type GenreSeriesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// only set when a single genre was requested
+	GenreId        int64                                `protobuf:"varint,1,opt,name=genreId,proto3" json:"genreId,omitempty"`
+	Name           string                               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Genres         []*GenreMsg                          `protobuf:"bytes,3,rep,name=genres,proto3" json:"genres,omitempty"`
+	ExcludedGenres []*GenreMsg                          `protobuf:"bytes,4,rep,name=excludedGenres,proto3" json:"excludedGenres,omitempty"`
+	GenreMatch     GenrePeriodDetailsRequest_GenreMatch `protobuf:"varint,5,opt,name=genreMatch,proto3,enum=movie.GenrePeriodDetailsRequest_GenreMatch" json:"genreMatch,omitempty"`
+	Buckets        []*SeriesBucket                      `protobuf:"bytes,6,rep,name=buckets,proto3" json:"buckets,omitempty"`
+}
+
+func (x *GenreSeriesReply) Reset() {
+	*x = GenreSeriesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenreSeriesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenreSeriesReply) ProtoMessage() {}
+
+func (x *GenreSeriesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenreSeriesReply.ProtoReflect.Descriptor instead.
+func (*GenreSeriesReply) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GenreSeriesReply) GetGenreId() int64 {
+	if x != nil {
+		return x.GenreId
+	}
+	return 0
+}
+
+func (x *GenreSeriesReply) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GenreSeriesReply) GetGenres() []*GenreMsg {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *GenreSeriesReply) GetExcludedGenres() []*GenreMsg {
+	if x != nil {
+		return x.ExcludedGenres
+	}
+	return nil
+}
+
+func (x *GenreSeriesReply) GetGenreMatch() GenrePeriodDetailsRequest_GenreMatch {
+	if x != nil {
+		return x.GenreMatch
+	}
+	return GenrePeriodDetailsRequest_MATCH_ALL
+}
+
+func (x *GenreSeriesReply) GetBuckets() []*SeriesBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+// movies released from startDate to endDate, both inclusive, the first and
+// last buckets are cut short by the period
+type SeriesBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartDate   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=startDate,proto3" json:"startDate,omitempty"`
+	EndDate     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=endDate,proto3" json:"endDate,omitempty"`
+	GenreMovies int64                  `protobuf:"varint,3,opt,name=genreMovies,proto3" json:"genreMovies,omitempty"`
+	TotalMovies int64                  `protobuf:"varint,4,opt,name=totalMovies,proto3" json:"totalMovies,omitempty"`
+	Pct         float32                `protobuf:"fixed32,5,opt,name=pct,proto3" json:"pct,omitempty"`
+	Stats       *MovieStats            `protobuf:"bytes,6,opt,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *SeriesBucket) Reset() {
+	*x = SeriesBucket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SeriesBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeriesBucket) ProtoMessage() {}
+
+func (x *SeriesBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeriesBucket.ProtoReflect.Descriptor instead.
+func (*SeriesBucket) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SeriesBucket) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *SeriesBucket) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *SeriesBucket) GetGenreMovies() int64 {
+	if x != nil {
+		return x.GenreMovies
+	}
+	return 0
+}
+
+func (x *SeriesBucket) GetTotalMovies() int64 {
+	if x != nil {
+		return x.TotalMovies
+	}
+	return 0
+}
+
+func (x *SeriesBucket) GetPct() float32 {
+	if x != nil {
+		return x.Pct
+	}
+	return 0
+}
+
+func (x *SeriesBucket) GetStats() *MovieStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type TopMoviesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// genres, period and filters of the movies to rank, sorting, paging,
+	// includeStats and denominator don't apply, movieMask trims the movies
+	Query  *GenrePeriodDetailsRequest `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Metric TopMoviesRequest_Metric    `protobuf:"varint,2,opt,name=metric,proto3,enum=movie.TopMoviesRequest_Metric" json:"metric,omitempty"`
+	// from 1 to 100
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *TopMoviesRequest) Reset() {
+	*x = TopMoviesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopMoviesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopMoviesRequest) ProtoMessage() {}
+
+func (x *TopMoviesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopMoviesRequest.ProtoReflect.Descriptor instead.
+func (*TopMoviesRequest) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TopMoviesRequest) GetQuery() *GenrePeriodDetailsRequest {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *TopMoviesRequest) GetMetric() TopMoviesRequest_Metric {
+	if x != nil {
+		return x.Metric
+	}
+	return TopMoviesRequest_RANK_REVENUE
+}
+
+func (x *TopMoviesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TopMoviesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// only set when a single genre was requested
+	GenreId        int64                                `protobuf:"varint,1,opt,name=genreId,proto3" json:"genreId,omitempty"`
+	Name           string                               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Genres         []*GenreMsg                          `protobuf:"bytes,3,rep,name=genres,proto3" json:"genres,omitempty"`
+	ExcludedGenres []*GenreMsg                          `protobuf:"bytes,4,rep,name=excludedGenres,proto3" json:"excludedGenres,omitempty"`
+	GenreMatch     GenrePeriodDetailsRequest_GenreMatch `protobuf:"varint,5,opt,name=genreMatch,proto3,enum=movie.GenrePeriodDetailsRequest_GenreMatch" json:"genreMatch,omitempty"`
+	Metric         TopMoviesRequest_Metric              `protobuf:"varint,6,opt,name=metric,proto3,enum=movie.TopMoviesRequest_Metric" json:"metric,omitempty"`
+	// best movies first, followed by up to limit more movies tied for the last
+	// rank, those with the lowest ids
+	Movies []*RankedMovieMsg `protobuf:"bytes,7,rep,name=movies,proto3" json:"movies,omitempty"`
+	// matching movies whose metric is known
+	RankedMovies int64 `protobuf:"varint,8,opt,name=rankedMovies,proto3" json:"rankedMovies,omitempty"`
+	// matching movies left out for an unknown (zero) revenue or budget, or
+	// for having no votes
+	UnrankedMovies int64 `protobuf:"varint,9,opt,name=unrankedMovies,proto3" json:"unrankedMovies,omitempty"`
+	// movies tied for the last rank left out of movies
+	OmittedTies int64 `protobuf:"varint,10,opt,name=omittedTies,proto3" json:"omittedTies,omitempty"`
+}
+
+func (x *TopMoviesReply) Reset() {
+	*x = TopMoviesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopMoviesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopMoviesReply) ProtoMessage() {}
+
+func (x *TopMoviesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopMoviesReply.ProtoReflect.Descriptor instead.
+func (*TopMoviesReply) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TopMoviesReply) GetGenreId() int64 {
+	if x != nil {
+		return x.GenreId
+	}
+	return 0
+}
+
+func (x *TopMoviesReply) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TopMoviesReply) GetGenres() []*GenreMsg {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *TopMoviesReply) GetExcludedGenres() []*GenreMsg {
+	if x != nil {
+		return x.ExcludedGenres
+	}
+	return nil
+}
+
+func (x *TopMoviesReply) GetGenreMatch() GenrePeriodDetailsRequest_GenreMatch {
+	if x != nil {
+		return x.GenreMatch
+	}
+	return GenrePeriodDetailsRequest_MATCH_ALL
+}
+
+func (x *TopMoviesReply) GetMetric() TopMoviesRequest_Metric {
+	if x != nil {
+		return x.Metric
+	}
+	return TopMoviesRequest_RANK_REVENUE
+}
+
+func (x *TopMoviesReply) GetMovies() []*RankedMovieMsg {
+	if x != nil {
+		return x.Movies
+	}
+	return nil
+}
+
+func (x *TopMoviesReply) GetRankedMovies() int64 {
+	if x != nil {
+		return x.RankedMovies
+	}
+	return 0
+}
+
+func (x *TopMoviesReply) GetUnrankedMovies() int64 {
+	if x != nil {
+		return x.UnrankedMovies
+	}
+	return 0
+}
+
+func (x *TopMoviesReply) GetOmittedTies() int64 {
+	if x != nil {
+		return x.OmittedTies
+	}
+	return 0
+}
+
+// movies of equal value share a rank, the next rank skips as many places
+type RankedMovieMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rank  int32     `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	Value float64   `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	Movie *MovieMsg `protobuf:"bytes,3,opt,name=movie,proto3" json:"movie,omitempty"`
+}
+
+func (x *RankedMovieMsg) Reset() {
+	*x = RankedMovieMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RankedMovieMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankedMovieMsg) ProtoMessage() {}
+
+func (x *RankedMovieMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankedMovieMsg.ProtoReflect.Descriptor instead.
+func (*RankedMovieMsg) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RankedMovieMsg) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *RankedMovieMsg) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *RankedMovieMsg) GetMovie() *MovieMsg {
+	if x != nil {
+		return x.Movie
+	}
+	return nil
+}
+
+type ComparePeriodsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// genres, filters and period of the current side, the baseline side only
+	// differs by its period. Paging doesn't apply and stats are always included.
+	Query             *GenrePeriodDetailsRequest `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	BaselineStartDate *timestamppb.Timestamp     `protobuf:"bytes,2,opt,name=baselineStartDate,proto3" json:"baselineStartDate,omitempty"`
+	BaselineEndDate   *timestamppb.Timestamp     `protobuf:"bytes,3,opt,name=baselineEndDate,proto3" json:"baselineEndDate,omitempty"`
+}
+
+func (x *ComparePeriodsRequest) Reset() {
+	*x = ComparePeriodsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ComparePeriodsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComparePeriodsRequest) ProtoMessage() {}
+
+func (x *ComparePeriodsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComparePeriodsRequest.ProtoReflect.Descriptor instead.
+func (*ComparePeriodsRequest) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ComparePeriodsRequest) GetQuery() *GenrePeriodDetailsRequest {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *ComparePeriodsRequest) GetBaselineStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BaselineStartDate
+	}
+	return nil
+}
+
+func (x *ComparePeriodsRequest) GetBaselineEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BaselineEndDate
+	}
+	return nil
+}
+
+type ComparePeriodsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Current  *GenrePeriodDetailsReply `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"`
+	Baseline *GenrePeriodDetailsReply `protobuf:"bytes,2,opt,name=baseline,proto3" json:"baseline,omitempty"`
+	// changes from the baseline to the current side
+	Deltas *PeriodDeltas `protobuf:"bytes,3,opt,name=deltas,proto3" json:"deltas,omitempty"`
+	// ids of the movies found on one side only, in ascending order
+	OnlyInCurrent  []int64 `protobuf:"varint,4,rep,packed,name=onlyInCurrent,proto3" json:"onlyInCurrent,omitempty"`
+	OnlyInBaseline []int64 `protobuf:"varint,5,rep,packed,name=onlyInBaseline,proto3" json:"onlyInBaseline,omitempty"`
+}
+
+func (x *ComparePeriodsReply) Reset() {
+	*x = ComparePeriodsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ComparePeriodsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComparePeriodsReply) ProtoMessage() {}
+
+func (x *ComparePeriodsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComparePeriodsReply.ProtoReflect.Descriptor instead.
+func (*ComparePeriodsReply) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ComparePeriodsReply) GetCurrent() *GenrePeriodDetailsReply {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *ComparePeriodsReply) GetBaseline() *GenrePeriodDetailsReply {
+	if x != nil {
+		return x.Baseline
+	}
+	return nil
+}
+
+func (x *ComparePeriodsReply) GetDeltas() *PeriodDeltas {
+	if x != nil {
+		return x.Deltas
+	}
+	return nil
+}
+
+func (x *ComparePeriodsReply) GetOnlyInCurrent() []int64 {
+	if x != nil {
+		return x.OnlyInCurrent
+	}
+	return nil
+}
+
+func (x *ComparePeriodsReply) GetOnlyInBaseline() []int64 {
+	if x != nil {
+		return x.OnlyInBaseline
+	}
+	return nil
+}
+
+type PeriodDeltas struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Movies        *Delta `protobuf:"bytes,1,opt,name=movies,proto3" json:"movies,omitempty"`
+	Pct           *Delta `protobuf:"bytes,2,opt,name=pct,proto3" json:"pct,omitempty"`
+	TotalRevenue  *Delta `protobuf:"bytes,3,opt,name=totalRevenue,proto3" json:"totalRevenue,omitempty"`
+	MeanRevenue   *Delta `protobuf:"bytes,4,opt,name=meanRevenue,proto3" json:"meanRevenue,omitempty"`
+	MedianRevenue *Delta `protobuf:"bytes,5,opt,name=medianRevenue,proto3" json:"medianRevenue,omitempty"`
+	P90Revenue    *Delta `protobuf:"bytes,6,opt,name=p90Revenue,proto3" json:"p90Revenue,omitempty"`
+	Roi           *Delta `protobuf:"bytes,7,opt,name=roi,proto3" json:"roi,omitempty"`
+}
+
+func (x *PeriodDeltas) Reset() {
+	*x = PeriodDeltas{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeriodDeltas) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeriodDeltas) ProtoMessage() {}
+
+func (x *PeriodDeltas) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeriodDeltas.ProtoReflect.Descriptor instead.
+func (*PeriodDeltas) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PeriodDeltas) GetMovies() *Delta {
+	if x != nil {
+		return x.Movies
+	}
+	return nil
+}
+
+func (x *PeriodDeltas) GetPct() *Delta {
+	if x != nil {
+		return x.Pct
+	}
+	return nil
+}
+
+func (x *PeriodDeltas) GetTotalRevenue() *Delta {
+	if x != nil {
+		return x.TotalRevenue
+	}
+	return nil
+}
+
+func (x *PeriodDeltas) GetMeanRevenue() *Delta {
+	if x != nil {
+		return x.MeanRevenue
+	}
+	return nil
+}
+
+func (x *PeriodDeltas) GetMedianRevenue() *Delta {
+	if x != nil {
+		return x.MedianRevenue
+	}
+	return nil
+}
+
+func (x *PeriodDeltas) GetP90Revenue() *Delta {
+	if x != nil {
+		return x.P90Revenue
+	}
+	return nil
+}
+
+func (x *PeriodDeltas) GetRoi() *Delta {
+	if x != nil {
+		return x.Roi
+	}
+	return nil
+}
+
+type Delta struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Absolute float64 `protobuf:"fixed64,1,opt,name=absolute,proto3" json:"absolute,omitempty"`
+	// change relative to the baseline value, 0.5 meaning 50%, unset when the
+	// baseline value is zero
+	Relative *float64 `protobuf:"fixed64,2,opt,name=relative,proto3,oneof" json:"relative,omitempty"`
+}
+
+func (x *Delta) Reset() {
+	*x = Delta{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Delta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Delta) ProtoMessage() {}
+
+func (x *Delta) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Delta.ProtoReflect.Descriptor instead.
+func (*Delta) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Delta) GetAbsolute() float64 {
+	if x != nil {
+		return x.Absolute
+	}
+	return 0
+}
+
+func (x *Delta) GetRelative() float64 {
+	if x != nil && x.Relative != nil {
+		return *x.Relative
+	}
+	return 0
+}
+
+type CompareGenresRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// genres to compare, every genre when both are empty
+	GenreIds   []int64  `protobuf:"varint,1,rep,packed,name=genreIds,proto3" json:"genreIds,omitempty"`
+	GenreNames []string `protobuf:"bytes,2,rep,name=genreNames,proto3" json:"genreNames,omitempty"`
+	// ISO 639-1 language of genre names, e.g. "de" or "pt-BR"
+	Language  string                 `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=startDate,proto3" json:"startDate,omitempty"`
+	EndDate   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=endDate,proto3" json:"endDate,omitempty"`
+	// movies must pass every filter and the expression, see
+	// GenrePeriodDetailsRequest
+	Filters    []*MovieFilter `protobuf:"bytes,6,rep,name=filters,proto3" json:"filters,omitempty"`
+	Expression string         `protobuf:"bytes,7,opt,name=expression,proto3" json:"expression,omitempty"`
+}
+
+func (x *CompareGenresRequest) Reset() {
+	*x = CompareGenresRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompareGenresRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareGenresRequest) ProtoMessage() {}
+
+func (x *CompareGenresRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareGenresRequest.ProtoReflect.Descriptor instead.
+func (*CompareGenresRequest) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CompareGenresRequest) GetGenreIds() []int64 {
+	if x != nil {
+		return x.GenreIds
+	}
+	return nil
+}
+
+func (x *CompareGenresRequest) GetGenreNames() []string {
+	if x != nil {
+		return x.GenreNames
+	}
+	return nil
+}
+
+func (x *CompareGenresRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *CompareGenresRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *CompareGenresRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *CompareGenresRequest) GetFilters() []*MovieFilter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *CompareGenresRequest) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+type CompareGenresReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// movies released in the period
+	TotalMovies int64 `protobuf:"varint,1,opt,name=totalMovies,proto3" json:"totalMovies,omitempty"`
+	// movies of any compared genre passing the filters
+	MatchingMovies int64 `protobuf:"varint,2,opt,name=matchingMovies,proto3" json:"matchingMovies,omitempty"`
+	// from the most to the least movies, a movie counts towards each of its
+	// genres
+	Genres []*GenreShareMsg `protobuf:"bytes,3,rep,name=genres,proto3" json:"genres,omitempty"`
+}
+
+func (x *CompareGenresReply) Reset() {
+	*x = CompareGenresReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompareGenresReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareGenresReply) ProtoMessage() {}
+
+func (x *CompareGenresReply) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareGenresReply.ProtoReflect.Descriptor instead.
+func (*CompareGenresReply) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CompareGenresReply) GetTotalMovies() int64 {
+	if x != nil {
+		return x.TotalMovies
+	}
+	return 0
+}
+
+func (x *CompareGenresReply) GetMatchingMovies() int64 {
+	if x != nil {
+		return x.MatchingMovies
+	}
+	return 0
+}
+
+func (x *CompareGenresReply) GetGenres() []*GenreShareMsg {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+type GenreShareMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     int64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name   string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Movies int64   `protobuf:"varint,3,opt,name=movies,proto3" json:"movies,omitempty"`
+	Pct    float32 `protobuf:"fixed32,4,opt,name=pct,proto3" json:"pct,omitempty"`
+	// movies with an unknown (zero) revenue only count in unknownRevenue
+	TotalRevenue   int64 `protobuf:"varint,5,opt,name=totalRevenue,proto3" json:"totalRevenue,omitempty"`
+	UnknownRevenue int64 `protobuf:"varint,6,opt,name=unknownRevenue,proto3" json:"unknownRevenue,omitempty"`
+}
+
+func (x *GenreShareMsg) Reset() {
+	*x = GenreShareMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenreShareMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenreShareMsg) ProtoMessage() {}
+
+func (x *GenreShareMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenreShareMsg.ProtoReflect.Descriptor instead.
+func (*GenreShareMsg) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GenreShareMsg) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GenreShareMsg) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GenreShareMsg) GetMovies() int64 {
+	if x != nil {
+		return x.Movies
+	}
+	return 0
+}
+
+func (x *GenreShareMsg) GetPct() float32 {
+	if x != nil {
+		return x.Pct
+	}
+	return 0
+}
+
+func (x *GenreShareMsg) GetTotalRevenue() int64 {
+	if x != nil {
+		return x.TotalRevenue
+	}
+	return 0
+}
+
+func (x *GenreShareMsg) GetUnknownRevenue() int64 {
+	if x != nil {
+		return x.UnknownRevenue
+	}
+	return 0
+}
+
+type GenreCooccurrenceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ISO 639-1 language of genre names, e.g. "de" or "pt-BR"
+	Language  string                 `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=startDate,proto3" json:"startDate,omitempty"`
+	EndDate   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=endDate,proto3" json:"endDate,omitempty"`
+	// also sum the revenue of the movies, which needs their details and costs
+	// a TMDB call for every movie missing from the cache
+	WeightByRevenue bool `protobuf:"varint,4,opt,name=weightByRevenue,proto3" json:"weightByRevenue,omitempty"`
+}
+
+func (x *GenreCooccurrenceRequest) Reset() {
+	*x = GenreCooccurrenceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenreCooccurrenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenreCooccurrenceRequest) ProtoMessage() {}
+
+func (x *GenreCooccurrenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenreCooccurrenceRequest.ProtoReflect.Descriptor instead.
+func (*GenreCooccurrenceRequest) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GenreCooccurrenceRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *GenreCooccurrenceRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *GenreCooccurrenceRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *GenreCooccurrenceRequest) GetWeightByRevenue() bool {
+	if x != nil {
+		return x.WeightByRevenue
+	}
+	return false
+}
+
+type GenreCooccurrenceReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// a row and a column for every genre, in this order
+	Genres []*GenreMsg        `protobuf:"bytes,1,rep,name=genres,proto3" json:"genres,omitempty"`
+	Rows   []*CooccurrenceRow `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
+	// movies released in the period
+	Movies int64 `protobuf:"varint,3,opt,name=movies,proto3" json:"movies,omitempty"`
+	// only set when weighted by revenue, movies with an unknown (zero) revenue
+	// only count in unknownRevenue
+	TotalRevenue   int64 `protobuf:"varint,4,opt,name=totalRevenue,proto3" json:"totalRevenue,omitempty"`
+	UnknownRevenue int64 `protobuf:"varint,5,opt,name=unknownRevenue,proto3" json:"unknownRevenue,omitempty"`
+}
+
+func (x *GenreCooccurrenceReply) Reset() {
+	*x = GenreCooccurrenceReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenreCooccurrenceReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenreCooccurrenceReply) ProtoMessage() {}
+
+func (x *GenreCooccurrenceReply) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenreCooccurrenceReply.ProtoReflect.Descriptor instead.
+func (*GenreCooccurrenceReply) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GenreCooccurrenceReply) GetGenres() []*GenreMsg {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *GenreCooccurrenceReply) GetRows() []*CooccurrenceRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *GenreCooccurrenceReply) GetMovies() int64 {
+	if x != nil {
+		return x.Movies
+	}
+	return 0
+}
+
+func (x *GenreCooccurrenceReply) GetTotalRevenue() int64 {
+	if x != nil {
+		return x.TotalRevenue
+	}
+	return 0
+}
+
+func (x *GenreCooccurrenceReply) GetUnknownRevenue() int64 {
+	if x != nil {
+		return x.UnknownRevenue
+	}
+	return 0
+}
+
+type CooccurrenceRow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cells []*CooccurrenceCell `protobuf:"bytes,1,rep,name=cells,proto3" json:"cells,omitempty"`
+}
+
+func (x *CooccurrenceRow) Reset() {
+	*x = CooccurrenceRow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CooccurrenceRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CooccurrenceRow) ProtoMessage() {}
+
+func (x *CooccurrenceRow) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CooccurrenceRow.ProtoReflect.Descriptor instead.
+func (*CooccurrenceRow) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CooccurrenceRow) GetCells() []*CooccurrenceCell {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+// movies having both the genre of the row and that of the column, the
+// diagonal describes the movies of a single genre
+type CooccurrenceCell struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Movies int64 `protobuf:"varint,1,opt,name=movies,proto3" json:"movies,omitempty"`
+	// share of movies having both genres relative to the product of the
+	// shares of each genre, above 1 meaning they are combined more often than
+	// if they were independent
+	Lift    float64 `protobuf:"fixed64,2,opt,name=lift,proto3" json:"lift,omitempty"`
+	Revenue int64   `protobuf:"varint,3,opt,name=revenue,proto3" json:"revenue,omitempty"`
+	// lift computed with shares of revenue instead of shares of movies
+	RevenueLift float64 `protobuf:"fixed64,4,opt,name=revenueLift,proto3" json:"revenueLift,omitempty"`
+}
+
+func (x *CooccurrenceCell) Reset() {
+	*x = CooccurrenceCell{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CooccurrenceCell) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CooccurrenceCell) ProtoMessage() {}
+
+func (x *CooccurrenceCell) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CooccurrenceCell.ProtoReflect.Descriptor instead.
+func (*CooccurrenceCell) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CooccurrenceCell) GetMovies() int64 {
+	if x != nil {
+		return x.Movies
+	}
+	return 0
+}
+
+func (x *CooccurrenceCell) GetLift() float64 {
+	if x != nil {
+		return x.Lift
+	}
+	return 0
+}
+
+func (x *CooccurrenceCell) GetRevenue() int64 {
+	if x != nil {
+		return x.Revenue
+	}
+	return 0
+}
+
+func (x *CooccurrenceCell) GetRevenueLift() float64 {
+	if x != nil {
+		return x.RevenueLift
+	}
+	return 0
+}
+
+type ListGenresRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Language string `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+}
+
+func (x *ListGenresRequest) Reset() {
+	*x = ListGenresRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListGenresRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGenresRequest) ProtoMessage() {}
+
+func (x *ListGenresRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGenresRequest.ProtoReflect.Descriptor instead.
+func (*ListGenresRequest) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListGenresRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type ListGenresReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Genres []*GenreMsg `protobuf:"bytes,1,rep,name=genres,proto3" json:"genres,omitempty"`
+}
+
+func (x *ListGenresReply) Reset() {
+	*x = ListGenresReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListGenresReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGenresReply) ProtoMessage() {}
+
+func (x *ListGenresReply) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGenresReply.ProtoReflect.Descriptor instead.
+func (*ListGenresReply) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListGenresReply) GetGenres() []*GenreMsg {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+type GenreMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GenreMsg) Reset() {
+	*x = GenreMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_server_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenreMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenreMsg) ProtoMessage() {}
+
+func (x *GenreMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_server_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenreMsg.ProtoReflect.Descriptor instead.
+func (*GenreMsg) Descriptor() ([]byte, []int) {
+	return file_pb_server_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GenreMsg) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GenreMsg) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_pb_server_proto protoreflect.FileDescriptor
+
+var file_pb_server_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x70, 0x62, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x05, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f,
+	0x6d, 0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8a, 0x0d, 0x0a, 0x19,
+	0x47, 0x65, 0x6e, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x65, 0x6e,
+	0x72, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x67, 0x65, 0x6e, 0x72,
+	0x65, 0x49, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65, 0x12, 0x34, 0x0a,
+	0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44,
+	0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x12, 0x5d, 0x0a,
+	0x14, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x6d, 0x6f,
+	0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x44,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x14, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x1c, 0x0a, 0x09,
+	0x67, 0x65, 0x6e, 0x72, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x49,
+	0x64, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x03, 0x52, 0x08, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x49,
+	0x64, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73,
+	0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x73, 0x12, 0x4b, 0x0a, 0x0a, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47,
+	0x65, 0x6e, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x0a, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12,
+	0x2a, 0x0a, 0x10, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x47, 0x65, 0x6e, 0x72, 0x65,
+	0x49, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x03, 0x52, 0x10, 0x65, 0x78, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x64, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x49, 0x64, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x65,
+	0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x64, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x72,
+	0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x4d, 0x61, 0x78, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x4d, 0x61, 0x78, 0x12, 0x30, 0x0a, 0x13, 0x72,
+	0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x4d, 0x69, 0x6e, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69,
+	0x76, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75,
+	0x65, 0x4d, 0x69, 0x6e, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x12, 0x30, 0x0a,
+	0x13, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x4d, 0x61, 0x78, 0x45, 0x78, 0x63, 0x6c, 0x75,
+	0x73, 0x69, 0x76, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x72, 0x65, 0x76, 0x65,
+	0x6e, 0x75, 0x65, 0x4d, 0x61, 0x78, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x12,
+	0x2c, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x52, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x42, 0x0a,
+	0x06, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2a, 0x2e,
+	0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f,
+	0x64, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x06, 0x73, 0x6f, 0x72, 0x74, 0x42,
+	0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18,
+	0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x14, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x38, 0x0a, 0x09, 0x6d,
+	0x6f, 0x76, 0x69, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x09, 0x6d, 0x6f, 0x76, 0x69,
+	0x65, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x18, 0x17, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x4e, 0x0a, 0x0b, 0x64, 0x65, 0x6e,
+	0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2c,
+	0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69,
+	0x6f, 0x64, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x44, 0x65, 0x6e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x0b, 0x64, 0x65,
+	0x6e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x28, 0x0a, 0x0f, 0x64, 0x65, 0x74,
+	0x65, 0x63, 0x74, 0x41, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x19, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0f, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x41, 0x6e, 0x6f, 0x6d, 0x61, 0x6c,
+	0x69, 0x65, 0x73, 0x12, 0x54, 0x0a, 0x0d, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x73, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2e, 0x2e, 0x6d, 0x6f, 0x76,
+	0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x55, 0x6e, 0x6b,
+	0x6e, 0x6f, 0x77, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x52, 0x0d, 0x75, 0x6e, 0x6b, 0x6e,
+	0x6f, 0x77, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x5c, 0x0a, 0x08, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x50, 0x5f, 0x4c, 0x54, 0x10, 0x00,
+	0x12, 0x09, 0x0a, 0x05, 0x4f, 0x50, 0x5f, 0x45, 0x51, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x4f,
+	0x50, 0x5f, 0x47, 0x54, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x50, 0x5f, 0x47, 0x45, 0x10,
+	0x03, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x50, 0x5f, 0x4c, 0x45, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05,
+	0x4f, 0x50, 0x5f, 0x4e, 0x45, 0x10, 0x05, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x50, 0x5f, 0x42, 0x45,
+	0x54, 0x57, 0x45, 0x45, 0x4e, 0x10, 0x06, 0x22, 0x2a, 0x0a, 0x0a, 0x47, 0x65, 0x6e, 0x72, 0x65,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x41,
+	0x4c, 0x4c, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x41, 0x4e,
+	0x59, 0x10, 0x01, 0x22, 0x79, 0x0a, 0x09, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x12, 0x0b, 0x0a, 0x07, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x49, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a,
+	0x0c, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x52, 0x45, 0x56, 0x45, 0x4e, 0x55, 0x45, 0x10, 0x01, 0x12,
+	0x15, 0x0a, 0x11, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x52, 0x45, 0x4c, 0x45, 0x41, 0x53, 0x45, 0x5f,
+	0x44, 0x41, 0x54, 0x45, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x54,
+	0x49, 0x54, 0x4c, 0x45, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x56,
+	0x4f, 0x54, 0x45, 0x5f, 0x41, 0x56, 0x45, 0x52, 0x41, 0x47, 0x45, 0x10, 0x04, 0x12, 0x0f, 0x0a,
+	0x0b, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x54, 0x10, 0x05, 0x22, 0x56,
+	0x0a, 0x0b, 0x44, 0x65, 0x6e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a,
+	0x12, 0x44, 0x45, 0x4e, 0x4f, 0x4d, 0x49, 0x4e, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x50, 0x45, 0x52,
+	0x49, 0x4f, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x44, 0x45, 0x4e, 0x4f, 0x4d, 0x49, 0x4e,
+	0x41, 0x54, 0x4f, 0x52, 0x5f, 0x47, 0x45, 0x4e, 0x52, 0x45, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14,
+	0x44, 0x45, 0x4e, 0x4f, 0x4d, 0x49, 0x4e, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x46, 0x49, 0x4c, 0x54,
+	0x45, 0x52, 0x45, 0x44, 0x10, 0x02, 0x22, 0x50, 0x0a, 0x0d, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77,
+	0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x13, 0x0a, 0x0f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x5f, 0x41, 0x53, 0x5f, 0x5a, 0x45, 0x52, 0x4f, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10,
+	0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x45, 0x58, 0x43, 0x4c, 0x55, 0x44, 0x45, 0x44,
+	0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x49, 0x4e,
+	0x43, 0x4c, 0x55, 0x44, 0x45, 0x44, 0x10, 0x02, 0x22, 0x93, 0x02, 0x0a, 0x0b, 0x4d, 0x6f, 0x76,
+	0x69, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x45,
+	0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x29, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x50, 0x65,
+	0x72, 0x69, 0x6f, 0x64, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x14, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x14, 0x0a, 0x04, 0x66, 0x6c, 0x61, 0x67, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x04, 0x66, 0x6c, 0x61, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x6d,
+	0x61, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x22, 0x0a,
+	0x0c, 0x6d, 0x69, 0x6e, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0c, 0x6d, 0x69, 0x6e, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76,
+	0x65, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x45, 0x78, 0x63, 0x6c,
+	0x75, 0x73, 0x69, 0x76, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xc0,
+	0x05, 0x0a, 0x17, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x65,
+	0x6e, 0x72, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x67, 0x65, 0x6e,
+	0x72, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x63, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x03, 0x70, 0x63, 0x74, 0x12, 0x27, 0x0a, 0x06, 0x6d, 0x6f,
+	0x76, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x6f, 0x76,
+	0x69, 0x65, 0x2e, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x4d, 0x73, 0x67, 0x52, 0x06, 0x6d, 0x6f, 0x76,
+	0x69, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x06, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72,
+	0x65, 0x4d, 0x73, 0x67, 0x52, 0x06, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x12, 0x37, 0x0a, 0x0e,
+	0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e,
+	0x72, 0x65, 0x4d, 0x73, 0x67, 0x52, 0x0e, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x47,
+	0x65, 0x6e, 0x72, 0x65, 0x73, 0x12, 0x4b, 0x0a, 0x0a, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x6d, 0x6f, 0x76, 0x69,
+	0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x47, 0x65, 0x6e, 0x72,
+	0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x0a, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50,
+	0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x76, 0x69,
+	0x65, 0x2e, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x73, 0x12, 0x4e, 0x0a, 0x0b, 0x64, 0x65, 0x6e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74,
+	0x6f, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2c, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65,
+	0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x65, 0x6e, 0x6f, 0x6d,
+	0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x0b, 0x64, 0x65, 0x6e, 0x6f, 0x6d, 0x69, 0x6e, 0x61,
+	0x74, 0x6f, 0x72, 0x12, 0x2c, 0x0a, 0x11, 0x64, 0x65, 0x6e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74,
+	0x6f, 0x72, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11,
+	0x64, 0x65, 0x6e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x4d, 0x6f, 0x76, 0x69, 0x65,
+	0x73, 0x12, 0x33, 0x0a, 0x09, 0x61, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x0d,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x4d, 0x6f, 0x76,
+	0x69, 0x65, 0x41, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x69, 0x65, 0x73, 0x52, 0x09, 0x61, 0x6e, 0x6f,
+	0x6d, 0x61, 0x6c, 0x69, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x61, 0x6e, 0x6f, 0x6d, 0x61, 0x6c,
+	0x6f, 0x75, 0x73, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0f, 0x61, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x6f, 0x75, 0x73, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73,
+	0x12, 0x3f, 0x0a, 0x0d, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e,
+	0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x73, 0x52, 0x0d, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x22, 0x60, 0x0a, 0x12, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x76, 0x65, 0x6e,
+	0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x74,
+	0x69, 0x6d, 0x65, 0x22, 0x5b, 0x0a, 0x0e, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x41, 0x6e, 0x6f, 0x6d,
+	0x61, 0x6c, 0x69, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x49, 0x64, 0x12,
+	0x2f, 0x0a, 0x09, 0x61, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x41, 0x6e, 0x6f, 0x6d, 0x61,
+	0x6c, 0x79, 0x4d, 0x73, 0x67, 0x52, 0x09, 0x61, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x69, 0x65, 0x73,
+	0x22, 0xaa, 0x01, 0x0a, 0x0a, 0x41, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x79, 0x4d, 0x73, 0x67, 0x12,
+	0x2a, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e,
+	0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x41, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x79, 0x4d, 0x73, 0x67,
+	0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x22, 0x58, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x13, 0x0a, 0x0f, 0x41,
+	0x4e, 0x4f, 0x4d, 0x41, 0x4c, 0x59, 0x5f, 0x4f, 0x55, 0x54, 0x4c, 0x49, 0x45, 0x52, 0x10, 0x00,
+	0x12, 0x21, 0x0a, 0x1d, 0x41, 0x4e, 0x4f, 0x4d, 0x41, 0x4c, 0x59, 0x5f, 0x52, 0x45, 0x56, 0x45,
+	0x4e, 0x55, 0x45, 0x5f, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x53, 0x5f, 0x42, 0x55, 0x44, 0x47, 0x45,
+	0x54, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x4e, 0x4f, 0x4d, 0x41, 0x4c, 0x59, 0x5f, 0x54,
+	0x49, 0x4e, 0x59, 0x5f, 0x52, 0x45, 0x56, 0x45, 0x4e, 0x55, 0x45, 0x10, 0x02, 0x22, 0xfe, 0x02,
+	0x0a, 0x0a, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x76, 0x65, 0x6e,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x52,
+	0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x61, 0x6e, 0x52, 0x65,
+	0x76, 0x65, 0x6e, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6d, 0x65, 0x61,
+	0x6e, 0x52, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x6d, 0x65, 0x64, 0x69,
+	0x61, 0x6e, 0x52, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0d, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x6e, 0x52, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x12, 0x1e,
+	0x0a, 0x0a, 0x70, 0x39, 0x30, 0x52, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0a, 0x70, 0x39, 0x30, 0x52, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x12, 0x20,
+	0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x72, 0x6f, 0x69, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x72,
+	0x6f, 0x69, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x75, 0x6e,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x61, 0x76, 0x65, 0x72,
+	0x61, 0x67, 0x65, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x76,
+	0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0d, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x12, 0x26, 0x0a, 0x0e, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x76, 0x65, 0x6e,
+	0x75, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77,
+	0x6e, 0x52, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x75, 0x6e, 0x6b, 0x6e,
+	0x6f, 0x77, 0x6e, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0d, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x22, 0xe7,
+	0x04, 0x0a, 0x08, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x4d, 0x73, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x44, 0x61, 0x74, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x44,
+	0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x62,
+	0x75, 0x64, 0x67, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x12,
+	0x27, 0x0a, 0x06, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x4d, 0x73, 0x67,
+	0x52, 0x06, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x76, 0x6f, 0x74, 0x65,
+	0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x76,
+	0x6f, 0x74, 0x65, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x76, 0x6f,
+	0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x76,
+	0x6f, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x6f, 0x70, 0x75,
+	0x6c, 0x61, 0x72, 0x69, 0x74, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0a, 0x70, 0x6f,
+	0x70, 0x75, 0x6c, 0x61, 0x72, 0x69, 0x74, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x6f, 0x73, 0x74,
+	0x65, 0x72, 0x50, 0x61, 0x74, 0x68, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6f,
+	0x73, 0x74, 0x65, 0x72, 0x50, 0x61, 0x74, 0x68, 0x12, 0x22, 0x0a, 0x0c, 0x62, 0x61, 0x63, 0x6b,
+	0x64, 0x72, 0x6f, 0x70, 0x50, 0x61, 0x74, 0x68, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x62, 0x61, 0x63, 0x6b, 0x64, 0x72, 0x6f, 0x70, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1a, 0x0a, 0x08,
+	0x6f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x12, 0x43, 0x0a, 0x13, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x18,
+	0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x6e, 0x79, 0x4d, 0x73, 0x67, 0x52, 0x13, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x12, 0x43, 0x0a,
+	0x13, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x76,
+	0x69, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x4d, 0x73, 0x67, 0x52, 0x13, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x12, 0x3c, 0x0a, 0x0f, 0x73, 0x70, 0x6f, 0x6b, 0x65, 0x6e, 0x4c, 0x61, 0x6e, 0x67,
+	0x75, 0x61, 0x67, 0x65, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x6f,
+	0x76, 0x69, 0x65, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x4d, 0x73, 0x67, 0x52,
+	0x0f, 0x73, 0x70, 0x6f, 0x6b, 0x65, 0x6e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x69, 0x6d, 0x64, 0x62, 0x49, 0x64, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x69, 0x6d, 0x64, 0x62, 0x49, 0x64, 0x22, 0x72, 0x0a, 0x0a, 0x43, 0x6f, 0x6d, 0x70,
+	0x61, 0x6e, 0x79, 0x4d, 0x73, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f,
+	0x67, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f,
+	0x67, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x12, 0x24, 0x0a, 0x0d, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6f,
+	0x72, 0x69, 0x67, 0x69, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x34, 0x0a, 0x0a,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x4d, 0x73, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0x35, 0x0a, 0x0b, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x4d, 0x73,
+	0x67, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xdc, 0x01, 0x0a, 0x12, 0x47, 0x65,
+	0x6e, 0x72, 0x65, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x36, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x50, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x47, 0x0a, 0x0b, 0x67, 0x72, 0x61, 0x6e,
+	0x75, 0x6c, 0x61, 0x72, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e,
+	0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x53, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x47, 0x72, 0x61, 0x6e, 0x75, 0x6c, 0x61,
+	0x72, 0x69, 0x74, 0x79, 0x52, 0x0b, 0x67, 0x72, 0x61, 0x6e, 0x75, 0x6c, 0x61, 0x72, 0x69, 0x74,
+	0x79, 0x22, 0x45, 0x0a, 0x0b, 0x47, 0x72, 0x61, 0x6e, 0x75, 0x6c, 0x61, 0x72, 0x69, 0x74, 0x79,
+	0x12, 0x0c, 0x0a, 0x08, 0x42, 0x59, 0x5f, 0x4d, 0x4f, 0x4e, 0x54, 0x48, 0x10, 0x00, 0x12, 0x0b,
+	0x0a, 0x07, 0x42, 0x59, 0x5f, 0x57, 0x45, 0x45, 0x4b, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x42,
+	0x59, 0x5f, 0x51, 0x55, 0x41, 0x52, 0x54, 0x45, 0x52, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x42,
+	0x59, 0x5f, 0x59, 0x45, 0x41, 0x52, 0x10, 0x03, 0x22, 0x9e, 0x02, 0x0a, 0x10, 0x47, 0x65, 0x6e,
+	0x72, 0x65, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a,
+	0x07, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x67, 0x65, 0x6e, 0x72, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x67,
+	0x65, 0x6e, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x6f,
+	0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x4d, 0x73, 0x67, 0x52, 0x06, 0x67, 0x65,
+	0x6e, 0x72, 0x65, 0x73, 0x12, 0x37, 0x0a, 0x0e, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64,
+	0x47, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d,
+	0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x4d, 0x73, 0x67, 0x52, 0x0e, 0x65,
+	0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x12, 0x4b, 0x0a,
+	0x0a, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x2b, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x50,
+	0x65, 0x72, 0x69, 0x6f, 0x64, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x0a,
+	0x67, 0x65, 0x6e, 0x72, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x2d, 0x0a, 0x07, 0x62, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x6f,
+	0x76, 0x69, 0x65, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74,
+	0x52, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x22, 0xfd, 0x01, 0x0a, 0x0c, 0x53, 0x65,
+	0x72, 0x69, 0x65, 0x73, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x44, 0x61, 0x74, 0x65, 0x12, 0x34, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x67, 0x65,
+	0x6e, 0x72, 0x65, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x70, 0x63, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x52, 0x03, 0x70, 0x63, 0x74,
+	0x12, 0x27, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0xf9, 0x01, 0x0a, 0x10, 0x54, 0x6f,
+	0x70, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36,
+	0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e,
+	0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f,
+	0x64, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x36, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x54,
+	0x6f, 0x70, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x22, 0x5f, 0x0a, 0x06, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x10,
+	0x0a, 0x0c, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x52, 0x45, 0x56, 0x45, 0x4e, 0x55, 0x45, 0x10, 0x00,
+	0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x54, 0x10,
+	0x01, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x52, 0x4f, 0x49, 0x10, 0x02, 0x12,
+	0x0f, 0x0a, 0x0b, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x52, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x03,
+	0x12, 0x13, 0x0a, 0x0f, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x50, 0x4f, 0x50, 0x55, 0x4c, 0x41, 0x52,
+	0x49, 0x54, 0x59, 0x10, 0x04, 0x22, 0xc2, 0x03, 0x0a, 0x0e, 0x54, 0x6f, 0x70, 0x4d, 0x6f, 0x76,
+	0x69, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x65, 0x6e, 0x72,
+	0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x67, 0x65, 0x6e, 0x72, 0x65,
+	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47,
+	0x65, 0x6e, 0x72, 0x65, 0x4d, 0x73, 0x67, 0x52, 0x06, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x12,
+	0x37, 0x0a, 0x0e, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x47, 0x65, 0x6e, 0x72, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e,
+	0x47, 0x65, 0x6e, 0x72, 0x65, 0x4d, 0x73, 0x67, 0x52, 0x0e, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x64, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x12, 0x4b, 0x0a, 0x0a, 0x67, 0x65, 0x6e, 0x72,
+	0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x6d,
+	0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x47,
+	0x65, 0x6e, 0x72, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x0a, 0x67, 0x65, 0x6e, 0x72, 0x65,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x36, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x54, 0x6f,
+	0x70, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x2d, 0x0a,
+	0x06, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x65, 0x64, 0x4d, 0x6f, 0x76, 0x69,
+	0x65, 0x4d, 0x73, 0x67, 0x52, 0x06, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c,
+	0x72, 0x61, 0x6e, 0x6b, 0x65, 0x64, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0c, 0x72, 0x61, 0x6e, 0x6b, 0x65, 0x64, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73,
+	0x12, 0x26, 0x0a, 0x0e, 0x75, 0x6e, 0x72, 0x61, 0x6e, 0x6b, 0x65, 0x64, 0x4d, 0x6f, 0x76, 0x69,
+	0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x75, 0x6e, 0x72, 0x61, 0x6e, 0x6b,
+	0x65, 0x64, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x6d, 0x69, 0x74,
+	0x74, 0x65, 0x64, 0x54, 0x69, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6f,
+	0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x54, 0x69, 0x65, 0x73, 0x22, 0x61, 0x0a, 0x0e, 0x52, 0x61,
+	0x6e, 0x6b, 0x65, 0x64, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x4d, 0x73, 0x67, 0x12, 0x12, 0x0a, 0x04,
+	0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x25, 0x0a, 0x05, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x4d, 0x6f,
+	0x76, 0x69, 0x65, 0x4d, 0x73, 0x67, 0x52, 0x05, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x22, 0xdf, 0x01,
+	0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47,
+	0x65, 0x6e, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12,
+	0x48, 0x0a, 0x11, 0x62, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x44, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x11, 0x62, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65, 0x12, 0x44, 0x0a, 0x0f, 0x62, 0x61, 0x73,
+	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0f,
+	0x62, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x22,
+	0x86, 0x02, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f,
+	0x64, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x38, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65,
+	0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x12, 0x3a, 0x0a, 0x08, 0x62, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72,
+	0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x52, 0x08, 0x62, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x2b, 0x0a,
+	0x06, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x44, 0x65, 0x6c, 0x74,
+	0x61, 0x73, 0x52, 0x06, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6f, 0x6e,
+	0x6c, 0x79, 0x49, 0x6e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x03, 0x52, 0x0d, 0x6f, 0x6e, 0x6c, 0x79, 0x49, 0x6e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x12, 0x26, 0x0a, 0x0e, 0x6f, 0x6e, 0x6c, 0x79, 0x49, 0x6e, 0x42, 0x61, 0x73, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x18, 0x05, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0e, 0x6f, 0x6e, 0x6c, 0x79, 0x49, 0x6e,
+	0x42, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0xb8, 0x02, 0x0a, 0x0c, 0x50, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x73, 0x12, 0x24, 0x0a, 0x06, 0x6d, 0x6f, 0x76,
+	0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x6f, 0x76, 0x69,
+	0x65, 0x2e, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x52, 0x06, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x12,
+	0x1e, 0x0a, 0x03, 0x70, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d,
+	0x6f, 0x76, 0x69, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x52, 0x03, 0x70, 0x63, 0x74, 0x12,
+	0x30, 0x0a, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x44, 0x65,
+	0x6c, 0x74, 0x61, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x76, 0x65, 0x6e, 0x75,
+	0x65, 0x12, 0x2e, 0x0a, 0x0b, 0x6d, 0x65, 0x61, 0x6e, 0x52, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x44,
+	0x65, 0x6c, 0x74, 0x61, 0x52, 0x0b, 0x6d, 0x65, 0x61, 0x6e, 0x52, 0x65, 0x76, 0x65, 0x6e, 0x75,
+	0x65, 0x12, 0x32, 0x0a, 0x0d, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x6e, 0x52, 0x65, 0x76, 0x65, 0x6e,
+	0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65,
+	0x2e, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x52, 0x0d, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x6e, 0x52, 0x65,
+	0x76, 0x65, 0x6e, 0x75, 0x65, 0x12, 0x2c, 0x0a, 0x0a, 0x70, 0x39, 0x30, 0x52, 0x65, 0x76, 0x65,
+	0x6e, 0x75, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x6f, 0x76, 0x69,
+	0x65, 0x2e, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x52, 0x0a, 0x70, 0x39, 0x30, 0x52, 0x65, 0x76, 0x65,
+	0x6e, 0x75, 0x65, 0x12, 0x1e, 0x0a, 0x03, 0x72, 0x6f, 0x69, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x52, 0x03,
+	0x72, 0x6f, 0x69, 0x22, 0x51, 0x0a, 0x05, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08,
+	0x61, 0x62, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08,
+	0x61, 0x62, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x08, 0x72, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x08, 0x72, 0x65,
+	0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x72, 0x65,
+	0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x22, 0xac, 0x02, 0x0a, 0x14, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x72, 0x65, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x49, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x03, 0x52, 0x08, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x49, 0x64, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x67,
+	0x65, 0x6e, 0x72, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0a, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x44, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74,
+	0x65, 0x12, 0x34, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07,
+	0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65,
+	0x2e, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x07, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x8c, 0x01, 0x0a, 0x12, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72,
+	0x65, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x20, 0x0a, 0x0b,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x12, 0x26,
+	0x0a, 0x0e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67,
+	0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x06, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47,
+	0x65, 0x6e, 0x72, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x4d, 0x73, 0x67, 0x52, 0x06, 0x67, 0x65,
+	0x6e, 0x72, 0x65, 0x73, 0x22, 0xa9, 0x01, 0x0a, 0x0d, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x53, 0x68,
+	0x61, 0x72, 0x65, 0x4d, 0x73, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x6f,
+	0x76, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6d, 0x6f, 0x76, 0x69,
+	0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52,
+	0x03, 0x70, 0x63, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x76,
+	0x65, 0x6e, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x52, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x75, 0x6e, 0x6b, 0x6e,
+	0x6f, 0x77, 0x6e, 0x52, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0e, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65,
+	0x22, 0xd0, 0x01, 0x0a, 0x18, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x43, 0x6f, 0x6f, 0x63, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x44, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44,
+	0x61, 0x74, 0x65, 0x12, 0x34, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x77, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x42, 0x79, 0x52, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x42, 0x79, 0x52, 0x65, 0x76, 0x65,
+	0x6e, 0x75, 0x65, 0x22, 0xd1, 0x01, 0x0a, 0x16, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x43, 0x6f, 0x6f,
+	0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27,
+	0x0a, 0x06, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x4d, 0x73, 0x67, 0x52,
+	0x06, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x43, 0x6f,
+	0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72,
+	0x6f, 0x77, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x12,
+	0x26, 0x0a, 0x0e, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x76, 0x65, 0x6e, 0x75,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e,
+	0x52, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x22, 0x40, 0x0a, 0x0f, 0x43, 0x6f, 0x6f, 0x63, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x6f, 0x77, 0x12, 0x2d, 0x0a, 0x05, 0x63, 0x65,
+	0x6c, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x6f, 0x76, 0x69,
+	0x65, 0x2e, 0x43, 0x6f, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x43, 0x65,
+	0x6c, 0x6c, 0x52, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x22, 0x7a, 0x0a, 0x10, 0x43, 0x6f, 0x6f,
+	0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x16, 0x0a,
+	0x06, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6d,
+	0x6f, 0x76, 0x69, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x66, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x04, 0x6c, 0x69, 0x66, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x76,
+	0x65, 0x6e, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x65, 0x76, 0x65,
+	0x6e, 0x75, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x4c, 0x69,
+	0x66, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75,
+	0x65, 0x4c, 0x69, 0x66, 0x74, 0x22, 0x2f, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x65, 0x6e,
+	0x72, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x3a, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x65,
+	0x6e, 0x72, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x67, 0x65, 0x6e,
+	0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x6f, 0x76, 0x69,
+	0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x4d, 0x73, 0x67, 0x52, 0x06, 0x67, 0x65, 0x6e, 0x72,
+	0x65, 0x73, 0x22, 0x2e, 0x0a, 0x08, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x4d, 0x73, 0x67, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x32, 0xa1, 0x04, 0x0a, 0x05, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x12, 0x5d, 0x0a, 0x17,
+	0x46, 0x65, 0x74, 0x63, 0x68, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x20, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e,
+	0x47, 0x65, 0x6e, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6d, 0x6f, 0x76, 0x69,
+	0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0a, 0x4c,
+	0x69, 0x73, 0x74, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x12, 0x18, 0x2e, 0x6d, 0x6f, 0x76, 0x69,
+	0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x47, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x48, 0x0a,
+	0x10, 0x46, 0x65, 0x74, 0x63, 0x68, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x53, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x19, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x53,
+	0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6d,
+	0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x72, 0x65, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65,
+	0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x72, 0x65, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x3d, 0x0a, 0x09, 0x54, 0x6f, 0x70, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x12,
+	0x17, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x54, 0x6f, 0x70, 0x4d, 0x6f, 0x76, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65,
+	0x2e, 0x54, 0x6f, 0x70, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x4c, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69,
+	0x6f, 0x64, 0x73, 0x12, 0x1c, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70,
+	0x61, 0x72, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72,
+	0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x55, 0x0a, 0x11, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x43, 0x6f, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x1f, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65, 0x6e,
+	0x72, 0x65, 0x43, 0x6f, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x2e, 0x47, 0x65,
+	0x6e, 0x72, 0x65, 0x43, 0x6f, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x66, 0x66, 0x61, 0x6e, 0x73, 0x68, 0x61, 0x68, 0x69, 0x64,
+	0x2f, 0x63, 0x6f, 0x6e, 0x76, 0x6f, 0x6c, 0x75, 0x74, 0x65, 0x64, 0x2d, 0x6d, 0x6f, 0x76, 0x69,
+	0x65, 0x2d, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pb_server_proto_rawDescOnce sync.Once
+	file_pb_server_proto_rawDescData = file_pb_server_proto_rawDesc
+)
+
+func file_pb_server_proto_rawDescGZIP() []byte {
+	file_pb_server_proto_rawDescOnce.Do(func() {
+		file_pb_server_proto_rawDescData = protoimpl.X.CompressGZIP(file_pb_server_proto_rawDescData)
+	})
+	return file_pb_server_proto_rawDescData
+}
+
+var file_pb_server_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_pb_server_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_pb_server_proto_goTypes = []interface{}{
+	(GenrePeriodDetailsRequest_Operator)(0),      // 0: movie.GenrePeriodDetailsRequest.Operator
+	(GenrePeriodDetailsRequest_GenreMatch)(0),    // 1: movie.GenrePeriodDetailsRequest.GenreMatch
+	(GenrePeriodDetailsRequest_SortField)(0),     // 2: movie.GenrePeriodDetailsRequest.SortField
+	(GenrePeriodDetailsRequest_Denominator)(0),   // 3: movie.GenrePeriodDetailsRequest.Denominator
+	(GenrePeriodDetailsRequest_UnknownValues)(0), // 4: movie.GenrePeriodDetailsRequest.UnknownValues
+	(AnomalyMsg_Kind)(0),                         // 5: movie.AnomalyMsg.Kind
+	(GenreSeriesRequest_Granularity)(0),          // 6: movie.GenreSeriesRequest.Granularity
+	(TopMoviesRequest_Metric)(0),                 // 7: movie.TopMoviesRequest.Metric
+	(*GenrePeriodDetailsRequest)(nil),            // 8: movie.GenrePeriodDetailsRequest
+	(*MovieFilter)(nil),                          // 9: movie.MovieFilter
+	(*GenrePeriodDetailsReply)(nil),              // 10: movie.GenrePeriodDetailsReply
+	(*UnknownValueCounts)(nil),                   // 11: movie.UnknownValueCounts
+	(*MovieAnomalies)(nil),                       // 12: movie.MovieAnomalies
+	(*AnomalyMsg)(nil),                           // 13: movie.AnomalyMsg
+	(*MovieStats)(nil),                           // 14: movie.MovieStats
+	(*MovieMsg)(nil),                             // 15: movie.MovieMsg
+	(*CompanyMsg)(nil),                           // 16: movie.CompanyMsg
+	(*CountryMsg)(nil),                           // 17: movie.CountryMsg
+	(*LanguageMsg)(nil),                          // 18: movie.LanguageMsg
+	(*GenreSeriesRequest)(nil),                   // 19: movie.GenreSeriesRequest
+	(*GenreSeriesReply)(nil),                     // 20: movie.GenreSeriesReply
+	(*SeriesBucket)(nil),                         // 21: movie.SeriesBucket
+	(*TopMoviesRequest)(nil),                     // 22: movie.TopMoviesRequest
+	(*TopMoviesReply)(nil),                       // 23: movie.TopMoviesReply
+	(*RankedMovieMsg)(nil),                       // 24: movie.RankedMovieMsg
+	(*ComparePeriodsRequest)(nil),                // 25: movie.ComparePeriodsRequest
+	(*ComparePeriodsReply)(nil),                  // 26: movie.ComparePeriodsReply
+	(*PeriodDeltas)(nil),                         // 27: movie.PeriodDeltas
+	(*Delta)(nil),                                // 28: movie.Delta
+	(*CompareGenresRequest)(nil),                 // 29: movie.CompareGenresRequest
+	(*CompareGenresReply)(nil),                   // 30: movie.CompareGenresReply
+	(*GenreShareMsg)(nil),                        // 31: movie.GenreShareMsg
+	(*GenreCooccurrenceRequest)(nil),             // 32: movie.GenreCooccurrenceRequest
+	(*GenreCooccurrenceReply)(nil),               // 33: movie.GenreCooccurrenceReply
+	(*CooccurrenceRow)(nil),                      // 34: movie.CooccurrenceRow
+	(*CooccurrenceCell)(nil),                     // 35: movie.CooccurrenceCell
+	(*ListGenresRequest)(nil),                    // 36: movie.ListGenresRequest
+	(*ListGenresReply)(nil),                      // 37: movie.ListGenresReply
+	(*GenreMsg)(nil),                             // 38: movie.GenreMsg
+	(*timestamppb.Timestamp)(nil),                // 39: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),                // 40: google.protobuf.FieldMask
+}
+var file_pb_server_proto_depIdxs = []int32{
+	39, // 0: movie.GenrePeriodDetailsRequest.startDate:type_name -> google.protobuf.Timestamp
+	39, // 1: movie.GenrePeriodDetailsRequest.endDate:type_name -> google.protobuf.Timestamp
+	0,  // 2: movie.GenrePeriodDetailsRequest.revenueCheckOperator:type_name -> movie.GenrePeriodDetailsRequest.Operator
+	1,  // 3: movie.GenrePeriodDetailsRequest.genreMatch:type_name -> movie.GenrePeriodDetailsRequest.GenreMatch
+	9,  // 4: movie.GenrePeriodDetailsRequest.filters:type_name -> movie.MovieFilter
+	2,  // 5: movie.GenrePeriodDetailsRequest.sortBy:type_name -> movie.GenrePeriodDetailsRequest.SortField
+	40, // 6: movie.GenrePeriodDetailsRequest.movieMask:type_name -> google.protobuf.FieldMask
+	3,  // 7: movie.GenrePeriodDetailsRequest.denominator:type_name -> movie.GenrePeriodDetailsRequest.Denominator
+	4,  // 8: movie.GenrePeriodDetailsRequest.unknownValues:type_name -> movie.GenrePeriodDetailsRequest.UnknownValues
+	0,  // 9: movie.MovieFilter.operator:type_name -> movie.GenrePeriodDetailsRequest.Operator
+	15, // 10: movie.GenrePeriodDetailsReply.movies:type_name -> movie.MovieMsg
+	38, // 11: movie.GenrePeriodDetailsReply.genres:type_name -> movie.GenreMsg
+	38, // 12: movie.GenrePeriodDetailsReply.excludedGenres:type_name -> movie.GenreMsg
+	1,  // 13: movie.GenrePeriodDetailsReply.genreMatch:type_name -> movie.GenrePeriodDetailsRequest.GenreMatch
+	14, // 14: movie.GenrePeriodDetailsReply.stats:type_name -> movie.MovieStats
+	3,  // 15: movie.GenrePeriodDetailsReply.denominator:type_name -> movie.GenrePeriodDetailsRequest.Denominator
+	12, // 16: movie.GenrePeriodDetailsReply.anomalies:type_name -> movie.MovieAnomalies
+	11, // 17: movie.GenrePeriodDetailsReply.unknownValues:type_name -> movie.UnknownValueCounts
+	13, // 18: movie.MovieAnomalies.anomalies:type_name -> movie.AnomalyMsg
+	5,  // 19: movie.AnomalyMsg.kind:type_name -> movie.AnomalyMsg.Kind
+	38, // 20: movie.MovieMsg.genres:type_name -> movie.GenreMsg
+	16, // 21: movie.MovieMsg.productionCompanies:type_name -> movie.CompanyMsg
+	17, // 22: movie.MovieMsg.productionCountries:type_name -> movie.CountryMsg
+	18, // 23: movie.MovieMsg.spokenLanguages:type_name -> movie.LanguageMsg
+	8,  // 24: movie.GenreSeriesRequest.query:type_name -> movie.GenrePeriodDetailsRequest
+	6,  // 25: movie.GenreSeriesRequest.granularity:type_name -> movie.GenreSeriesRequest.Granularity
+	38, // 26: movie.GenreSeriesReply.genres:type_name -> movie.GenreMsg
+	38, // 27: movie.GenreSeriesReply.excludedGenres:type_name -> movie.GenreMsg
+	1,  // 28: movie.GenreSeriesReply.genreMatch:type_name -> movie.GenrePeriodDetailsRequest.GenreMatch
+	21, // 29: movie.GenreSeriesReply.buckets:type_name -> movie.SeriesBucket
+	39, // 30: movie.SeriesBucket.startDate:type_name -> google.protobuf.Timestamp
+	39, // 31: movie.SeriesBucket.endDate:type_name -> google.protobuf.Timestamp
+	14, // 32: movie.SeriesBucket.stats:type_name -> movie.MovieStats
+	8,  // 33: movie.TopMoviesRequest.query:type_name -> movie.GenrePeriodDetailsRequest
+	7,  // 34: movie.TopMoviesRequest.metric:type_name -> movie.TopMoviesRequest.Metric
+	38, // 35: movie.TopMoviesReply.genres:type_name -> movie.GenreMsg
+	38, // 36: movie.TopMoviesReply.excludedGenres:type_name -> movie.GenreMsg
+	1,  // 37: movie.TopMoviesReply.genreMatch:type_name -> movie.GenrePeriodDetailsRequest.GenreMatch
+	7,  // 38: movie.TopMoviesReply.metric:type_name -> movie.TopMoviesRequest.Metric
+	24, // 39: movie.TopMoviesReply.movies:type_name -> movie.RankedMovieMsg
+	15, // 40: movie.RankedMovieMsg.movie:type_name -> movie.MovieMsg
+	8,  // 41: movie.ComparePeriodsRequest.query:type_name -> movie.GenrePeriodDetailsRequest
+	39, // 42: movie.ComparePeriodsRequest.baselineStartDate:type_name -> google.protobuf.Timestamp
+	39, // 43: movie.ComparePeriodsRequest.baselineEndDate:type_name -> google.protobuf.Timestamp
+	10, // 44: movie.ComparePeriodsReply.current:type_name -> movie.GenrePeriodDetailsReply
+	10, // 45: movie.ComparePeriodsReply.baseline:type_name -> movie.GenrePeriodDetailsReply
+	27, // 46: movie.ComparePeriodsReply.deltas:type_name -> movie.PeriodDeltas
+	28, // 47: movie.PeriodDeltas.movies:type_name -> movie.Delta
+	28, // 48: movie.PeriodDeltas.pct:type_name -> movie.Delta
+	28, // 49: movie.PeriodDeltas.totalRevenue:type_name -> movie.Delta
+	28, // 50: movie.PeriodDeltas.meanRevenue:type_name -> movie.Delta
+	28, // 51: movie.PeriodDeltas.medianRevenue:type_name -> movie.Delta
+	28, // 52: movie.PeriodDeltas.p90Revenue:type_name -> movie.Delta
+	28, // 53: movie.PeriodDeltas.roi:type_name -> movie.Delta
+	39, // 54: movie.CompareGenresRequest.startDate:type_name -> google.protobuf.Timestamp
+	39, // 55: movie.CompareGenresRequest.endDate:type_name -> google.protobuf.Timestamp
+	9,  // 56: movie.CompareGenresRequest.filters:type_name -> movie.MovieFilter
+	31, // 57: movie.CompareGenresReply.genres:type_name -> movie.GenreShareMsg
+	39, // 58: movie.GenreCooccurrenceRequest.startDate:type_name -> google.protobuf.Timestamp
+	39, // 59: movie.GenreCooccurrenceRequest.endDate:type_name -> google.protobuf.Timestamp
+	38, // 60: movie.GenreCooccurrenceReply.genres:type_name -> movie.GenreMsg
+	34, // 61: movie.GenreCooccurrenceReply.rows:type_name -> movie.CooccurrenceRow
+	35, // 62: movie.CooccurrenceRow.cells:type_name -> movie.CooccurrenceCell
+	38, // 63: movie.ListGenresReply.genres:type_name -> movie.GenreMsg
+	8,  // 64: movie.Movie.FetchGenrePeriodDetails:input_type -> movie.GenrePeriodDetailsRequest
+	36, // 65: movie.Movie.ListGenres:input_type -> movie.ListGenresRequest
+	19, // 66: movie.Movie.FetchGenreSeries:input_type -> movie.GenreSeriesRequest
+	29, // 67: movie.Movie.CompareGenres:input_type -> movie.CompareGenresRequest
+	22, // 68: movie.Movie.TopMovies:input_type -> movie.TopMoviesRequest
+	25, // 69: movie.Movie.ComparePeriods:input_type -> movie.ComparePeriodsRequest
+	32, // 70: movie.Movie.GenreCooccurrence:input_type -> movie.GenreCooccurrenceRequest
+	10, // 71: movie.Movie.FetchGenrePeriodDetails:output_type -> movie.GenrePeriodDetailsReply
+	37, // 72: movie.Movie.ListGenres:output_type -> movie.ListGenresReply
+	20, // 73: movie.Movie.FetchGenreSeries:output_type -> movie.GenreSeriesReply
+	30, // 74: movie.Movie.CompareGenres:output_type -> movie.CompareGenresReply
+	23, // 75: movie.Movie.TopMovies:output_type -> movie.TopMoviesReply
+	26, // 76: movie.Movie.ComparePeriods:output_type -> movie.ComparePeriodsReply
+	33, // 77: movie.Movie.GenreCooccurrence:output_type -> movie.GenreCooccurrenceReply
+	71, // [71:78] is the sub-list for method output_type
+	64, // [64:71] is the sub-list for method input_type
+	64, // [64:64] is the sub-list for extension type_name
+	64, // [64:64] is the sub-list for extension extendee
+	0,  // [0:64] is the sub-list for field type_name
+}
+
+func init() { file_pb_server_proto_init() }
+func file_pb_server_proto_init() {
+	if File_pb_server_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pb_server_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenrePeriodDetailsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MovieFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenrePeriodDetailsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnknownValueCounts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MovieAnomalies); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
 			case 2:
 				return &v.unknownFields
 			default:
 				return nil
 			}
 		}
-		file_pb_server_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_pb_server_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AnomalyMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MovieStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MovieMsg); i {
 			case 0:
 				return &v.state
@@ -424,14 +3967,296 @@ func file_pb_server_proto_init() {
 				return nil
 			}
 		}
+		file_pb_server_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CountryMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LanguageMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenreSeriesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenreSeriesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeriesBucket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopMoviesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopMoviesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RankedMovieMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ComparePeriodsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ComparePeriodsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeriodDeltas); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Delta); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompareGenresRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompareGenresReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenreShareMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenreCooccurrenceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenreCooccurrenceReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CooccurrenceRow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CooccurrenceCell); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListGenresRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListGenresReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_server_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenreMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_pb_server_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*MovieFilter_Number)(nil),
+		(*MovieFilter_Text)(nil),
+		(*MovieFilter_Flag)(nil),
 	}
+	file_pb_server_proto_msgTypes[20].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pb_server_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   3,
+			NumEnums:      8,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
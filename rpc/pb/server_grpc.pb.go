@@ -19,6 +19,12 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type MovieClient interface {
 	FetchGenrePeriodDetails(ctx context.Context, in *GenrePeriodDetailsRequest, opts ...grpc.CallOption) (*GenrePeriodDetailsReply, error)
+	ListGenres(ctx context.Context, in *ListGenresRequest, opts ...grpc.CallOption) (*ListGenresReply, error)
+	FetchGenreSeries(ctx context.Context, in *GenreSeriesRequest, opts ...grpc.CallOption) (*GenreSeriesReply, error)
+	CompareGenres(ctx context.Context, in *CompareGenresRequest, opts ...grpc.CallOption) (*CompareGenresReply, error)
+	TopMovies(ctx context.Context, in *TopMoviesRequest, opts ...grpc.CallOption) (*TopMoviesReply, error)
+	ComparePeriods(ctx context.Context, in *ComparePeriodsRequest, opts ...grpc.CallOption) (*ComparePeriodsReply, error)
+	GenreCooccurrence(ctx context.Context, in *GenreCooccurrenceRequest, opts ...grpc.CallOption) (*GenreCooccurrenceReply, error)
 }
 
 type movieClient struct {
@@ -38,11 +44,71 @@ func (c *movieClient) FetchGenrePeriodDetails(ctx context.Context, in *GenrePeri
 	return out, nil
 }
 
+func (c *movieClient) ListGenres(ctx context.Context, in *ListGenresRequest, opts ...grpc.CallOption) (*ListGenresReply, error) {
+	out := new(ListGenresReply)
+	err := c.cc.Invoke(ctx, "/movie.Movie/ListGenres", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *movieClient) FetchGenreSeries(ctx context.Context, in *GenreSeriesRequest, opts ...grpc.CallOption) (*GenreSeriesReply, error) {
+	out := new(GenreSeriesReply)
+	err := c.cc.Invoke(ctx, "/movie.Movie/FetchGenreSeries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *movieClient) CompareGenres(ctx context.Context, in *CompareGenresRequest, opts ...grpc.CallOption) (*CompareGenresReply, error) {
+	out := new(CompareGenresReply)
+	err := c.cc.Invoke(ctx, "/movie.Movie/CompareGenres", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *movieClient) TopMovies(ctx context.Context, in *TopMoviesRequest, opts ...grpc.CallOption) (*TopMoviesReply, error) {
+	out := new(TopMoviesReply)
+	err := c.cc.Invoke(ctx, "/movie.Movie/TopMovies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *movieClient) ComparePeriods(ctx context.Context, in *ComparePeriodsRequest, opts ...grpc.CallOption) (*ComparePeriodsReply, error) {
+	out := new(ComparePeriodsReply)
+	err := c.cc.Invoke(ctx, "/movie.Movie/ComparePeriods", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *movieClient) GenreCooccurrence(ctx context.Context, in *GenreCooccurrenceRequest, opts ...grpc.CallOption) (*GenreCooccurrenceReply, error) {
+	out := new(GenreCooccurrenceReply)
+	err := c.cc.Invoke(ctx, "/movie.Movie/GenreCooccurrence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MovieServer is the server API for Movie service.
 // All implementations must embed UnimplementedMovieServer
 // for forward compatibility
 type MovieServer interface {
 	FetchGenrePeriodDetails(context.Context, *GenrePeriodDetailsRequest) (*GenrePeriodDetailsReply, error)
+	ListGenres(context.Context, *ListGenresRequest) (*ListGenresReply, error)
+	FetchGenreSeries(context.Context, *GenreSeriesRequest) (*GenreSeriesReply, error)
+	CompareGenres(context.Context, *CompareGenresRequest) (*CompareGenresReply, error)
+	TopMovies(context.Context, *TopMoviesRequest) (*TopMoviesReply, error)
+	ComparePeriods(context.Context, *ComparePeriodsRequest) (*ComparePeriodsReply, error)
+	GenreCooccurrence(context.Context, *GenreCooccurrenceRequest) (*GenreCooccurrenceReply, error)
 	mustEmbedUnimplementedMovieServer()
 }
 
@@ -53,6 +119,24 @@ type UnimplementedMovieServer struct {
 func (UnimplementedMovieServer) FetchGenrePeriodDetails(context.Context, *GenrePeriodDetailsRequest) (*GenrePeriodDetailsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FetchGenrePeriodDetails not implemented")
 }
+func (UnimplementedMovieServer) ListGenres(context.Context, *ListGenresRequest) (*ListGenresReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGenres not implemented")
+}
+func (UnimplementedMovieServer) FetchGenreSeries(context.Context, *GenreSeriesRequest) (*GenreSeriesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchGenreSeries not implemented")
+}
+func (UnimplementedMovieServer) CompareGenres(context.Context, *CompareGenresRequest) (*CompareGenresReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareGenres not implemented")
+}
+func (UnimplementedMovieServer) TopMovies(context.Context, *TopMoviesRequest) (*TopMoviesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TopMovies not implemented")
+}
+func (UnimplementedMovieServer) ComparePeriods(context.Context, *ComparePeriodsRequest) (*ComparePeriodsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ComparePeriods not implemented")
+}
+func (UnimplementedMovieServer) GenreCooccurrence(context.Context, *GenreCooccurrenceRequest) (*GenreCooccurrenceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenreCooccurrence not implemented")
+}
 func (UnimplementedMovieServer) mustEmbedUnimplementedMovieServer() {}
 
 // UnsafeMovieServer may be embedded to opt out of forward compatibility for this service.
@@ -84,6 +168,114 @@ func _Movie_FetchGenrePeriodDetails_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Movie_ListGenres_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGenresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieServer).ListGenres(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.Movie/ListGenres",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieServer).ListGenres(ctx, req.(*ListGenresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Movie_FetchGenreSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenreSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieServer).FetchGenreSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.Movie/FetchGenreSeries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieServer).FetchGenreSeries(ctx, req.(*GenreSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Movie_CompareGenres_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareGenresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieServer).CompareGenres(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.Movie/CompareGenres",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieServer).CompareGenres(ctx, req.(*CompareGenresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Movie_TopMovies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopMoviesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieServer).TopMovies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.Movie/TopMovies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieServer).TopMovies(ctx, req.(*TopMoviesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Movie_ComparePeriods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComparePeriodsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieServer).ComparePeriods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.Movie/ComparePeriods",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieServer).ComparePeriods(ctx, req.(*ComparePeriodsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Movie_GenreCooccurrence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenreCooccurrenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieServer).GenreCooccurrence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.Movie/GenreCooccurrence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieServer).GenreCooccurrence(ctx, req.(*GenreCooccurrenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Movie_ServiceDesc is the grpc.ServiceDesc for Movie service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -95,6 +287,30 @@ var Movie_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FetchGenrePeriodDetails",
 			Handler:    _Movie_FetchGenrePeriodDetails_Handler,
 		},
+		{
+			MethodName: "ListGenres",
+			Handler:    _Movie_ListGenres_Handler,
+		},
+		{
+			MethodName: "FetchGenreSeries",
+			Handler:    _Movie_FetchGenreSeries_Handler,
+		},
+		{
+			MethodName: "CompareGenres",
+			Handler:    _Movie_CompareGenres_Handler,
+		},
+		{
+			MethodName: "TopMovies",
+			Handler:    _Movie_TopMovies_Handler,
+		},
+		{
+			MethodName: "ComparePeriods",
+			Handler:    _Movie_ComparePeriods_Handler,
+		},
+		{
+			MethodName: "GenreCooccurrence",
+			Handler:    _Movie_GenreCooccurrence_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pb/server.proto",
@@ -0,0 +1,166 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/affanshahid/convoluted-movie-finder/core"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const quotaDayFormat = "2006-01-02"
+
+// limiter admits calls per caller, the authenticated principal or the peer IP
+// when authentication is disabled. Each caller gets a fixed window request rate
+// and a daily budget of TMDB calls which is charged after every call with the
+// number of TMDB requests it actually made. Counters live in etcd so the
+// limits hold across replicas. If etcd can't be reached calls are let through.
+type limiter struct {
+	store      core.QuotaStore
+	rate       int64
+	window     time.Duration
+	dailyQuota int64
+	now        func() time.Time
+}
+
+// newLimiter admits rate calls per window and dailyQuota TMDB calls per day to
+// every caller
+func newLimiter(store core.QuotaStore, rate int64, window time.Duration, dailyQuota int64) *limiter {
+	return &limiter{
+		store:      store,
+		rate:       rate,
+		window:     window,
+		dailyQuota: dailyQuota,
+		now:        time.Now,
+	}
+}
+
+func (l *limiter) admit(ctx context.Context) (context.Context, func(), error) {
+	caller := callerFromContext(ctx)
+	now := l.now().UTC()
+
+	windowStart := now.Truncate(l.window)
+	rateKey := fmt.Sprintf("rate_%s_%d", caller, windowStart.Unix())
+	count, err := l.store.IncrementCounter(ctx, rateKey, 1, 2*l.window)
+	if err != nil {
+		log.Printf("unable to check rate limit for %s: %v", caller, err)
+	} else if count > l.rate {
+		return nil, nil, resourceExhausted(
+			fmt.Sprintf("rate limit of %d requests per %s exceeded", l.rate, l.window),
+			caller,
+			"requests per "+l.window.String(),
+			windowStart.Add(l.window).Sub(now),
+		)
+	}
+
+	quotaKey := fmt.Sprintf("quota_%s_%s", caller, now.Format(quotaDayFormat))
+	used, err := l.store.GetCounter(ctx, quotaKey)
+	if err != nil {
+		log.Printf("unable to check daily quota for %s: %v", caller, err)
+	} else if used >= l.dailyQuota {
+		tomorrow := now.Truncate(24 * time.Hour).Add(24 * time.Hour)
+		return nil, nil, resourceExhausted(
+			fmt.Sprintf("daily quota of %d TMDB calls exhausted", l.dailyQuota),
+			caller,
+			"TMDB calls per day",
+			tomorrow.Sub(now),
+		)
+	}
+
+	usage := new(core.Usage)
+	charge := func() {
+		calls := usage.TmdbCalls()
+		if calls == 0 {
+			return
+		}
+
+		chargeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := l.store.IncrementCounter(chargeCtx, quotaKey, calls, 48*time.Hour); err != nil {
+			log.Printf("unable to charge %d TMDB calls to %s: %v", calls, caller, err)
+		}
+	}
+
+	return core.WithUsage(ctx, usage), charge, nil
+}
+
+func (l *limiter) unaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if strings.HasPrefix(info.FullMethod, healthMethodPrefix) {
+		return handler(ctx, req)
+	}
+
+	limitedCtx, charge, err := l.admit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer charge()
+
+	return handler(limitedCtx, req)
+}
+
+func (l *limiter) streamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if strings.HasPrefix(info.FullMethod, healthMethodPrefix) {
+		return handler(srv, ss)
+	}
+
+	limitedCtx, charge, err := l.admit(ss.Context())
+	if err != nil {
+		return err
+	}
+	defer charge()
+
+	return handler(srv, &contextServerStream{ss, limitedCtx})
+}
+
+func callerFromContext(ctx context.Context) string {
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		return "principal_" + principal
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "ip_unknown"
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+
+	return "ip_" + host
+}
+
+func resourceExhausted(msg, subject, description string, retryAfter time.Duration) error {
+	st, err := status.New(codes.ResourceExhausted, msg).WithDetails(
+		&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{
+				{Subject: subject, Description: description},
+			},
+		},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)},
+	)
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, msg)
+	}
+
+	return st.Err()
+}
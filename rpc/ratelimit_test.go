@@ -0,0 +1,288 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/affanshahid/convoluted-movie-finder/core"
+	"github.com/affanshahid/convoluted-movie-finder/core/mocks"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// memoryQuotaStore is a QuotaStore backed by a map, failing every call with err
+// when set
+type memoryQuotaStore struct {
+	mu       sync.Mutex
+	counters map[string]int64
+	ttls     map[string]time.Duration
+	err      error
+}
+
+func newMemoryQuotaStore() *memoryQuotaStore {
+	return &memoryQuotaStore{counters: map[string]int64{}, ttls: map[string]time.Duration{}}
+}
+
+func (s *memoryQuotaStore) IncrementCounter(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counters[key] += delta
+	s.ttls[key] = ttl
+
+	return s.counters[key], nil
+}
+
+func (s *memoryQuotaStore) GetCounter(ctx context.Context, key string) (int64, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.counters[key], nil
+}
+
+var limiterNow = time.Date(2021, 6, 1, 10, 0, 30, 0, time.UTC)
+
+func testLimiter(store core.QuotaStore, rate, dailyQuota int64) *limiter {
+	l := newLimiter(store, rate, time.Minute, dailyQuota)
+	l.now = func() time.Time { return limiterNow }
+
+	return l
+}
+
+func principalContext(principal string) context.Context {
+	return context.WithValue(context.Background(), principalKey{}, principal)
+}
+
+func quotaDetails(t *testing.T, err error) (*errdetails.RetryInfo, *errdetails.QuotaFailure) {
+	t.Helper()
+
+	var retryInfo *errdetails.RetryInfo
+	var quotaFailure *errdetails.QuotaFailure
+	for _, detail := range status.Convert(err).Details() {
+		switch d := detail.(type) {
+		case *errdetails.RetryInfo:
+			retryInfo = d
+		case *errdetails.QuotaFailure:
+			quotaFailure = d
+		}
+	}
+
+	if retryInfo == nil || quotaFailure == nil {
+		t.Fatalf("expected RetryInfo and QuotaFailure details, got %v", status.Convert(err).Details())
+	}
+
+	return retryInfo, quotaFailure
+}
+
+func TestCallerFromContext(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		ctx      context.Context
+		expected string
+	}{
+		{"principal", principalContext("alice"), "principal_alice"},
+		{
+			"peer address",
+			peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 4242}}),
+			"ip_10.0.0.1",
+		},
+		{"no peer", context.Background(), "ip_unknown"},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, c.expected, callerFromContext(c.ctx))
+		})
+	}
+}
+
+func TestLimiterRateLimit(t *testing.T) {
+	t.Parallel()
+	store := newMemoryQuotaStore()
+	l := testLimiter(store, 2, 100)
+
+	for i := 0; i < 2; i++ {
+		_, _, err := l.admit(principalContext("alice"))
+		assert.Nilf(t, err, "expected call %d to be admitted", i+1)
+	}
+
+	_, _, err := l.admit(principalContext("alice"))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	retryInfo, quotaFailure := quotaDetails(t, err)
+	assert.Equal(t, 30*time.Second, retryInfo.RetryDelay.AsDuration())
+	assert.Equal(t, "principal_alice", quotaFailure.Violations[0].Subject)
+	assert.Equal(t, "requests per 1m0s", quotaFailure.Violations[0].Description)
+
+	windowKey := fmt.Sprintf("rate_principal_alice_%d", time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC).Unix())
+	assert.Equal(t, int64(3), store.counters[windowKey])
+	assert.Equal(t, 2*time.Minute, store.ttls[windowKey])
+
+	_, _, err = l.admit(principalContext("bob"))
+	assert.Nilf(t, err, "expected other callers to be admitted")
+}
+
+func TestLimiterRateWindowStartsOver(t *testing.T) {
+	t.Parallel()
+	store := newMemoryQuotaStore()
+	l := testLimiter(store, 1, 100)
+
+	_, _, err := l.admit(principalContext("alice"))
+	assert.Nilf(t, err, "expected error to be nil")
+
+	l.now = func() time.Time { return limiterNow.Add(time.Minute) }
+	_, _, err = l.admit(principalContext("alice"))
+	assert.Nilf(t, err, "expected the next window to admit the call")
+}
+
+func TestLimiterDailyQuota(t *testing.T) {
+	t.Parallel()
+	store := newMemoryQuotaStore()
+	store.counters["quota_principal_alice_2021-06-01"] = 100
+	l := testLimiter(store, 10, 100)
+
+	_, _, err := l.admit(principalContext("alice"))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	retryInfo, quotaFailure := quotaDetails(t, err)
+	assert.Equal(t, 13*time.Hour+59*time.Minute+30*time.Second, retryInfo.RetryDelay.AsDuration())
+	assert.Equal(t, "principal_alice", quotaFailure.Violations[0].Subject)
+	assert.Equal(t, "TMDB calls per day", quotaFailure.Violations[0].Description)
+}
+
+func TestLimiterChargesTmdbCalls(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		calls    int
+		expected int64
+	}{
+		{"no calls", 0, 0},
+		{"two calls", 2, 2},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			store := newMemoryQuotaStore()
+			l := testLimiter(store, 10, 100)
+
+			var nilmap map[string]string
+			mockClient := new(mocks.TmdbClient)
+			mockClient.On("GetGenreMovieList", nilmap).Return(&tmdb.GenreMovieList{}, nil)
+			svc := core.NewMovieService(mockClient, new(mocks.MovieCache), core.WithGenreCacheTTL(0))
+
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				for i := 0; i < c.calls; i++ {
+					if _, err := svc.ListGenres(ctx, ""); err != nil {
+						return nil, err
+					}
+				}
+				return "ok", nil
+			}
+
+			_, err := l.unaryInterceptor(principalContext("alice"), nil, &grpc.UnaryServerInfo{FullMethod: "/Movie/ListGenres"}, handler)
+
+			assert.Nilf(t, err, "expected error to be nil")
+			assert.Equal(t, c.expected, store.counters["quota_principal_alice_2021-06-01"])
+			if c.expected > 0 {
+				assert.Equal(t, 48*time.Hour, store.ttls["quota_principal_alice_2021-06-01"])
+			}
+		})
+	}
+}
+
+func TestLimiterStreamInterceptor(t *testing.T) {
+	t.Parallel()
+	store := newMemoryQuotaStore()
+	l := testLimiter(store, 1, 100)
+	info := &grpc.StreamServerInfo{FullMethod: "/Movie/Watch"}
+
+	var streamCtx context.Context
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		streamCtx = ss.Context()
+		return nil
+	}
+
+	err := l.streamInterceptor(nil, &fakeServerStream{ctx: principalContext("alice")}, info, handler)
+	assert.Nilf(t, err, "expected error to be nil")
+	principal, _ := PrincipalFromContext(streamCtx)
+	assert.Equal(t, "alice", principal)
+
+	err = l.streamInterceptor(nil, &fakeServerStream{ctx: principalContext("alice")}, info, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestLimiterSkipsHealthChecks(t *testing.T) {
+	t.Parallel()
+	store := newMemoryQuotaStore()
+	l := testLimiter(store, 0, 0)
+
+	_, err := l.unaryInterceptor(
+		principalContext("alice"),
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"},
+		func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil },
+	)
+
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.Empty(t, store.counters)
+}
+
+// not parallel since it captures the standard logger
+func TestLimiterFailsOpen(t *testing.T) {
+	var logs bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&logs)
+
+	store := newMemoryQuotaStore()
+	store.err = errors.New("etcd down")
+	l := testLimiter(store, 0, 0)
+
+	var nilmap map[string]string
+	mockClient := new(mocks.TmdbClient)
+	mockClient.On("GetGenreMovieList", nilmap).Return(&tmdb.GenreMovieList{}, nil)
+	svc := core.NewMovieService(mockClient, new(mocks.MovieCache), core.WithGenreCacheTTL(0))
+
+	called := false
+	_, err := l.unaryInterceptor(
+		principalContext("alice"),
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: "/Movie/ListGenres"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			called = true
+			return svc.ListGenres(ctx, "")
+		},
+	)
+
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.True(t, called, "expected the call to be let through")
+	assert.Contains(t, logs.String(), "unable to check rate limit for principal_alice: etcd down")
+	assert.Contains(t, logs.String(), "unable to check daily quota for principal_alice: etcd down")
+	assert.Contains(t, logs.String(), "unable to charge 1 TMDB calls to principal_alice: etcd down")
+}
@@ -3,28 +3,132 @@
 package rpc
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/affanshahid/configo"
 	"github.com/affanshahid/convoluted-movie-finder/core"
 	"github.com/affanshahid/convoluted-movie-finder/rpc/pb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
-func Serve(movieService *core.MovieService) error {
+// Serve blocks until the server fails or receives SIGINT/SIGTERM, in which case
+// it drains in-flight queries before returning. keyStore is consulted in
+// addition to the configured auth_api_keys and may be nil, quotaStore is only
+// used when rate limiting is enabled.
+func Serve(movieService *core.MovieService, keyStore core.KeyStore, quotaStore core.QuotaStore) error {
 	listener, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", configo.MustGetInt("grpc_port")))
 	if err != nil {
 		return err
 	}
 
-	server := grpc.NewServer()
-	pb.RegisterMovieServer(server, &movieServer{service: movieService})
+	tlsConfig, err := serverTLSConfig()
+	if err != nil {
+		return err
+	}
+
+	var opts []grpc.ServerOption
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	var unaryInterceptors []grpc.UnaryServerInterceptor
+	var streamInterceptors []grpc.StreamServerInterceptor
+	if configo.MustGetBool("auth_enabled") {
+		auth := newAuthenticator(configo.MustGetStringMap("auth_api_keys"), keyStore)
+		unaryInterceptors = append(unaryInterceptors, auth.unaryInterceptor)
+		streamInterceptors = append(streamInterceptors, auth.streamInterceptor)
+	}
+	if configo.MustGetBool("rate_limit_enabled") {
+		limiter := newLimiter(
+			quotaStore,
+			configo.MustGetInt64("rate_limit_requests"),
+			configo.MustGetDuration("rate_limit_window"),
+			configo.MustGetInt64("daily_tmdb_quota"),
+		)
+		unaryInterceptors = append(unaryInterceptors, limiter.unaryInterceptor)
+		streamInterceptors = append(streamInterceptors, limiter.streamInterceptor)
+	}
+	unaryInterceptors = append(unaryInterceptors, loggingUnaryInterceptor)
+
+	opts = append(
+		opts,
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+
+	server := grpc.NewServer(opts...)
+	movieSrv := &movieServer{service: movieService}
+	pb.RegisterMovieServer(server, movieSrv)
+
+	healthServer := newHealthServer()
+	healthpb.RegisterHealthServer(server, healthServer)
 
+	if configo.MustGetBool("grpc_reflection") {
+		reflection.Register(server)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go watchReadiness(ctx, healthServer, movieService)
+
+	serveErr := make(chan error, 2)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
 	fmt.Printf("Server running on: %s\n", listener.Addr())
-	if err := server.Serve(listener); err != nil {
+
+	var httpServer *http.Server
+	if configo.MustGetBool("gateway_enabled") {
+		gw, err := newGateway(movieSrv, chainUnaryInterceptors(unaryInterceptors))
+		if err != nil {
+			server.Stop()
+			return err
+		}
+
+		httpServer = &http.Server{
+			Addr:      fmt.Sprintf("0.0.0.0:%d", configo.MustGetInt("http_port")),
+			Handler:   gw,
+			TLSConfig: tlsConfig,
+		}
+
+		go func() {
+			var err error
+			if tlsConfig != nil {
+				err = httpServer.ListenAndServeTLS("", "")
+			} else {
+				err = httpServer.ListenAndServe()
+			}
+
+			if !errors.Is(err, http.ErrServerClosed) {
+				serveErr <- err
+			}
+		}()
+		fmt.Printf("Gateway running on: %s\n", httpServer.Addr)
+	}
+
+	select {
+	case err := <-serveErr:
+		server.Stop()
+		if httpServer != nil {
+			httpServer.Close()
+		}
 		return err
+	case <-ctx.Done():
 	}
 
-	return nil
+	// restore default signal handling so a second signal terminates immediately
+	stop()
+	shutdown(server, httpServer, healthServer)
+
+	return <-serveErr
 }
@@ -0,0 +1,57 @@
+package rpc
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/affanshahid/configo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+)
+
+// shutdown drains the servers in three steps: readiness is withdrawn so that
+// load balancers stop routing new traffic, new requests are refused while
+// running ones are allowed to finish, and once the grace period runs out the
+// remaining ones are cancelled. httpServer may be nil.
+func shutdown(server *grpc.Server, httpServer *http.Server, healthServer *health.Server) {
+	drainDelay := configo.MustGetDuration("shutdown_drain_delay")
+	gracePeriod := configo.MustGetDuration("shutdown_grace_period")
+
+	log.Printf("shutting down, draining for %s", drainDelay)
+	healthServer.Shutdown()
+	time.Sleep(drainDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), gracePeriod)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	if httpServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := httpServer.Shutdown(ctx); err != nil {
+				httpServer.Close()
+			}
+		}()
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		log.Printf("all in-flight queries finished")
+	case <-ctx.Done():
+		log.Printf("grace period of %s elapsed, cancelling in-flight queries", gracePeriod)
+		server.Stop()
+		<-stopped
+	}
+
+	wg.Wait()
+}
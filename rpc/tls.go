@@ -0,0 +1,169 @@
+package rpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/affanshahid/configo"
+)
+
+// certReloader serves the certificate and client CA bundle found on disk and
+// picks up replacements without a restart. Files are checked for changes on
+// every handshake; if a reload fails the previous material keeps being served.
+type certReloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mu        sync.Mutex
+	modTimes  []time.Time
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+}
+
+func newCertReloader(certFile, keyFile, clientCAFile string) (*certReloader, error) {
+	r := &certReloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+	}
+
+	modTimes, err := r.fileModTimes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.load(modTimes); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *certReloader) files() []string {
+	files := []string{r.certFile, r.keyFile}
+	if r.clientCAFile != "" {
+		files = append(files, r.clientCAFile)
+	}
+	return files
+}
+
+func (r *certReloader) fileModTimes() ([]time.Time, error) {
+	files := r.files()
+	modTimes := make([]time.Time, len(files))
+	for i, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+
+		modTimes[i] = info.ModTime()
+	}
+
+	return modTimes, nil
+}
+
+// changed reports whether any file has a different modification time than
+// when it was loaded, earlier times included so that preserved timestamps and
+// rollbacks to older certificates are picked up
+func (r *certReloader) changed(modTimes []time.Time) bool {
+	for i, modTime := range modTimes {
+		if !modTime.Equal(r.modTimes[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *certReloader) load(modTimes []time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		clientCAs, err = loadCertPool(r.clientCAFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+
+	return nil
+}
+
+func (r *certReloader) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	modTimes, err := r.fileModTimes()
+	if err == nil && r.changed(modTimes) {
+		err = r.load(modTimes)
+		if err == nil {
+			log.Printf("reloaded TLS certificates")
+		}
+	}
+	if err != nil {
+		log.Printf("unable to reload TLS certificates, keeping the current ones: %v", err)
+	}
+
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*r.cert},
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+
+	if r.clientCAs != nil {
+		config.ClientCAs = r.clientCAs
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return config, nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", file)
+	}
+
+	return pool, nil
+}
+
+// serverTLSConfig returns the TLS configuration described by the tls_*
+// settings, or nil when TLS is disabled
+func serverTLSConfig() (*tls.Config, error) {
+	if !configo.MustGetBool("tls_enabled") {
+		return nil, nil
+	}
+
+	certFile := configo.MustGetString("tls_cert_file")
+	keyFile := configo.MustGetString("tls_key_file")
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("tls_cert_file and tls_key_file are required when tls_enabled is set")
+	}
+
+	reloader, err := newCertReloader(certFile, keyFile, configo.MustGetString("tls_client_ca_file"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{GetConfigForClient: reloader.getConfigForClient}, nil
+}
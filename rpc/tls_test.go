@@ -0,0 +1,174 @@
+package rpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeCert writes a self-signed certificate for name and its key to dir and
+// returns the DER of the certificate
+func writeCert(t *testing.T, dir, name string) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal("unable to generate key", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal("unable to create certificate", err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal("unable to marshal key", err)
+	}
+
+	writePEM(t, filepath.Join(dir, "cert.pem"), "CERTIFICATE", der)
+	writePEM(t, filepath.Join(dir, "key.pem"), "EC PRIVATE KEY", keyDer)
+
+	return der
+}
+
+func writePEM(t *testing.T, file, blockType string, der []byte) {
+	t.Helper()
+
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := ioutil.WriteFile(file, data, 0600); err != nil {
+		t.Fatal("unable to write", file, err)
+	}
+}
+
+func setModTime(t *testing.T, modTime time.Time, files ...string) {
+	t.Helper()
+
+	for _, file := range files {
+		if err := os.Chtimes(file, modTime, modTime); err != nil {
+			t.Fatal("unable to set modification time of", file, err)
+		}
+	}
+}
+
+func servedCert(t *testing.T, r *certReloader) []byte {
+	t.Helper()
+
+	config, err := r.getConfigForClient(nil)
+	if err != nil {
+		t.Fatal("unable to get config", err)
+	}
+
+	return config.Certificates[0].Certificate[0]
+}
+
+func TestCertReloaderReloadsRewrittenFiles(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		offset time.Duration
+	}{
+		{"newer files", time.Hour},
+		{"older files", -time.Hour},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+			loaded := time.Now().Truncate(time.Second)
+
+			first := writeCert(t, dir, "first")
+			setModTime(t, loaded, certFile, keyFile)
+
+			r, err := newCertReloader(certFile, keyFile, "")
+			assert.Nilf(t, err, "expected error to be nil")
+			assert.Equal(t, first, servedCert(t, r))
+
+			second := writeCert(t, dir, "second")
+			setModTime(t, loaded.Add(c.offset), certFile, keyFile)
+
+			assert.Equal(t, second, servedCert(t, r))
+		})
+	}
+}
+
+func TestCertReloaderKeepsCertificateWhenReloadFails(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	first := writeCert(t, dir, "first")
+	r, err := newCertReloader(certFile, keyFile, "")
+	assert.Nilf(t, err, "expected error to be nil")
+
+	assert.Nil(t, ioutil.WriteFile(keyFile, []byte("garbage"), 0600))
+	setModTime(t, time.Now().Add(time.Hour), keyFile)
+
+	assert.Equal(t, first, servedCert(t, r))
+}
+
+func TestCertReloaderRequiresClientCertificates(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	writeCert(t, dir, "server")
+	caFile := filepath.Join(dir, "ca.pem")
+	caDer := writeCert(t, t.TempDir(), "client-ca")
+	writePEM(t, caFile, "CERTIFICATE", caDer)
+
+	withoutCA, err := newCertReloader(certFile, keyFile, "")
+	assert.Nilf(t, err, "expected error to be nil")
+	config, err := withoutCA.getConfigForClient(nil)
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.Equal(t, tls.NoClientCert, config.ClientAuth)
+	assert.Nil(t, config.ClientCAs)
+
+	withCA, err := newCertReloader(certFile, keyFile, caFile)
+	assert.Nilf(t, err, "expected error to be nil")
+	config, err = withCA.getConfigForClient(nil)
+	assert.Nilf(t, err, "expected error to be nil")
+	assert.Equal(t, tls.RequireAndVerifyClientCert, config.ClientAuth)
+	assert.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+
+	ca, err := x509.ParseCertificate(caDer)
+	assert.Nilf(t, err, "expected error to be nil")
+	_, err = ca.Verify(x509.VerifyOptions{Roots: config.ClientCAs, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}})
+	assert.Nilf(t, err, "expected the client CA bundle to be trusted")
+}
+
+func TestCertReloaderRejectsEmptyClientCABundle(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeCert(t, dir, "server")
+	caFile := filepath.Join(dir, "ca.pem")
+	assert.Nil(t, ioutil.WriteFile(caFile, []byte("no certificates"), 0600))
+
+	_, err := newCertReloader(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), caFile)
+	assert.NotNil(t, err)
+}
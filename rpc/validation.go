@@ -0,0 +1,181 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/affanshahid/convoluted-movie-finder/core"
+	"github.com/affanshahid/convoluted-movie-finder/rpc/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// validateGenrePeriodDetailsRequest rejects what can't be represented once the
+// request is converted into a core query, the query itself is validated by
+// MovieService
+func validateGenrePeriodDetailsRequest(in *pb.GenrePeriodDetailsRequest) error {
+	var violations []core.FieldViolation
+
+	violations = appendTimestampViolations(violations, "startDate", in.StartDate)
+	violations = appendTimestampViolations(violations, "endDate", in.EndDate)
+
+	if _, ok := pb.GenrePeriodDetailsRequest_Operator_name[int32(in.RevenueCheckOperator)]; !ok {
+		violations = append(violations, core.FieldViolation{
+			Field:       "revenueCheckOperator",
+			Description: fmt.Sprintf("unknown operator %d", in.RevenueCheckOperator),
+		})
+	}
+
+	if _, ok := pb.GenrePeriodDetailsRequest_GenreMatch_name[int32(in.GenreMatch)]; !ok {
+		violations = append(violations, core.FieldViolation{
+			Field:       "genreMatch",
+			Description: fmt.Sprintf("unknown genre match %d", in.GenreMatch),
+		})
+	}
+
+	if _, ok := pb.GenrePeriodDetailsRequest_SortField_name[int32(in.SortBy)]; !ok {
+		violations = append(violations, core.FieldViolation{
+			Field:       "sortBy",
+			Description: fmt.Sprintf("unknown sort field %d", in.SortBy),
+		})
+	}
+
+	if _, ok := pb.GenrePeriodDetailsRequest_Denominator_name[int32(in.Denominator)]; !ok {
+		violations = append(violations, core.FieldViolation{
+			Field:       "denominator",
+			Description: fmt.Sprintf("unknown denominator %d", in.Denominator),
+		})
+	}
+
+	if _, ok := pb.GenrePeriodDetailsRequest_UnknownValues_name[int32(in.UnknownValues)]; !ok {
+		violations = append(violations, core.FieldViolation{
+			Field:       "unknownValues",
+			Description: fmt.Sprintf("unknown policy %d", in.UnknownValues),
+		})
+	}
+
+	violations = appendMaskViolations(violations, "movieMask", in.MovieMask, &pb.MovieMsg{})
+
+	if len(violations) > 0 {
+		return &core.InvalidArgumentError{Kind: core.ErrInvalidRequest, Violations: violations}
+	}
+
+	return nil
+}
+
+// validateGenreSeriesRequest checks the embedded query like a genre period
+// request, a missing query is reported as missing dates
+func validateGenreSeriesRequest(in *pb.GenreSeriesRequest) error {
+	query := in.GetQuery()
+	if query == nil {
+		query = &pb.GenrePeriodDetailsRequest{}
+	}
+
+	var violations []core.FieldViolation
+	var invalidErr *core.InvalidArgumentError
+	if err := validateGenrePeriodDetailsRequest(query); errors.As(err, &invalidErr) {
+		violations = invalidErr.Violations
+	}
+
+	if _, ok := pb.GenreSeriesRequest_Granularity_name[int32(in.Granularity)]; !ok {
+		violations = append(violations, core.FieldViolation{
+			Field:       "granularity",
+			Description: fmt.Sprintf("unknown granularity %d", in.Granularity),
+		})
+	}
+
+	if len(violations) > 0 {
+		return &core.InvalidArgumentError{Kind: core.ErrInvalidRequest, Violations: violations}
+	}
+
+	return nil
+}
+
+// validateTopMoviesRequest checks the embedded query like a genre period
+// request, a missing query is reported as missing dates
+func validateTopMoviesRequest(in *pb.TopMoviesRequest) error {
+	query := in.GetQuery()
+	if query == nil {
+		query = &pb.GenrePeriodDetailsRequest{}
+	}
+
+	var violations []core.FieldViolation
+	var invalidErr *core.InvalidArgumentError
+	if err := validateGenrePeriodDetailsRequest(query); errors.As(err, &invalidErr) {
+		violations = invalidErr.Violations
+	}
+
+	if _, ok := pb.TopMoviesRequest_Metric_name[int32(in.Metric)]; !ok {
+		violations = append(violations, core.FieldViolation{
+			Field:       "metric",
+			Description: fmt.Sprintf("unknown ranking metric %d", in.Metric),
+		})
+	}
+
+	if len(violations) > 0 {
+		return &core.InvalidArgumentError{Kind: core.ErrInvalidRequest, Violations: violations}
+	}
+
+	return nil
+}
+
+// validateComparePeriodsRequest checks the embedded query like a genre period
+// request, a missing query is reported as missing dates
+func validateComparePeriodsRequest(in *pb.ComparePeriodsRequest) error {
+	query := in.GetQuery()
+	if query == nil {
+		query = &pb.GenrePeriodDetailsRequest{}
+	}
+
+	var violations []core.FieldViolation
+	var invalidErr *core.InvalidArgumentError
+	if err := validateGenrePeriodDetailsRequest(query); errors.As(err, &invalidErr) {
+		violations = invalidErr.Violations
+	}
+
+	violations = appendTimestampViolations(violations, "baselineStartDate", in.BaselineStartDate)
+	violations = appendTimestampViolations(violations, "baselineEndDate", in.BaselineEndDate)
+
+	if len(violations) > 0 {
+		return &core.InvalidArgumentError{Kind: core.ErrInvalidRequest, Violations: violations}
+	}
+
+	return nil
+}
+
+func validateCompareGenresRequest(in *pb.CompareGenresRequest) error {
+	var violations []core.FieldViolation
+
+	violations = appendTimestampViolations(violations, "startDate", in.StartDate)
+	violations = appendTimestampViolations(violations, "endDate", in.EndDate)
+
+	if len(violations) > 0 {
+		return &core.InvalidArgumentError{Kind: core.ErrInvalidRequest, Violations: violations}
+	}
+
+	return nil
+}
+
+func validateGenreCooccurrenceRequest(in *pb.GenreCooccurrenceRequest) error {
+	var violations []core.FieldViolation
+
+	violations = appendTimestampViolations(violations, "startDate", in.StartDate)
+	violations = appendTimestampViolations(violations, "endDate", in.EndDate)
+
+	if len(violations) > 0 {
+		return &core.InvalidArgumentError{Kind: core.ErrInvalidRequest, Violations: violations}
+	}
+
+	return nil
+}
+
+func appendTimestampViolations(violations []core.FieldViolation, field string, ts *timestamppb.Timestamp) []core.FieldViolation {
+	if ts == nil {
+		return append(violations, core.FieldViolation{Field: field, Description: "is required"})
+	}
+
+	if err := ts.CheckValid(); err != nil {
+		return append(violations, core.FieldViolation{Field: field, Description: err.Error()})
+	}
+
+	return violations
+}
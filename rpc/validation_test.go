@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/affanshahid/convoluted-movie-finder/core"
+	"github.com/affanshahid/convoluted-movie-finder/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestValidateGenrePeriodDetailsRequest(t *testing.T) {
+	t.Parallel()
+	err := validateGenrePeriodDetailsRequest(&pb.GenrePeriodDetailsRequest{
+		GenreId:   28,
+		StartDate: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+		EndDate:   timestamppb.New(time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)),
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestValidateGenrePeriodDetailsRequestRejectsMissingFields(t *testing.T) {
+	t.Parallel()
+	err := validateGenrePeriodDetailsRequest(&pb.GenrePeriodDetailsRequest{
+		GenreId:              28,
+		RevenueCheckOperator: 42,
+	})
+
+	var invalidErr *core.InvalidArgumentError
+	assert.True(t, errors.As(err, &invalidErr), "expected an InvalidArgumentError")
+	assert.Equal(t, []core.FieldViolation{
+		{Field: "startDate", Description: "is required"},
+		{Field: "endDate", Description: "is required"},
+		{Field: "revenueCheckOperator", Description: "unknown operator 42"},
+	}, invalidErr.Violations)
+}
+
+func TestValidateGenreSeriesRequest(t *testing.T) {
+	t.Parallel()
+	err := validateGenreSeriesRequest(&pb.GenreSeriesRequest{Granularity: 7})
+
+	var invalidErr *core.InvalidArgumentError
+	assert.True(t, errors.As(err, &invalidErr), "expected an InvalidArgumentError")
+	assert.Equal(t, []core.FieldViolation{
+		{Field: "startDate", Description: "is required"},
+		{Field: "endDate", Description: "is required"},
+		{Field: "granularity", Description: "unknown granularity 7"},
+	}, invalidErr.Violations)
+}
+
+func TestValidateTopMoviesRequest(t *testing.T) {
+	t.Parallel()
+	err := validateTopMoviesRequest(&pb.TopMoviesRequest{Metric: 9, Limit: 10})
+
+	var invalidErr *core.InvalidArgumentError
+	assert.True(t, errors.As(err, &invalidErr), "expected an InvalidArgumentError")
+	assert.Equal(t, []core.FieldViolation{
+		{Field: "startDate", Description: "is required"},
+		{Field: "endDate", Description: "is required"},
+		{Field: "metric", Description: "unknown ranking metric 9"},
+	}, invalidErr.Violations)
+}
+
+func TestValidateComparePeriodsRequest(t *testing.T) {
+	t.Parallel()
+	err := validateComparePeriodsRequest(&pb.ComparePeriodsRequest{})
+
+	var invalidErr *core.InvalidArgumentError
+	assert.True(t, errors.As(err, &invalidErr), "expected an InvalidArgumentError")
+	assert.Equal(t, []core.FieldViolation{
+		{Field: "startDate", Description: "is required"},
+		{Field: "endDate", Description: "is required"},
+		{Field: "baselineStartDate", Description: "is required"},
+		{Field: "baselineEndDate", Description: "is required"},
+	}, invalidErr.Violations)
+}